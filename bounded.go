@@ -0,0 +1,129 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/bounded.go
+
+package gorng
+
+import "math/bits"
+
+// Unbiased bounded integers.  Every method here is built on uint64n(), so the
+// results for a given seed depend only on the 64-bit words of the stream and
+// not on the width of the type being returned.
+
+// Returns a uniform value in [0, n).  Panics if n == 0.
+func (rng *ShaRing) Uint64N(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64N")
+	}
+	return uint64n(rng, n)
+}
+
+// Returns a uniform value in [0, n).  Panics if n == 0.
+func (rng *ShaRing) Uint32N(n uint32) uint32 {
+	if n == 0 {
+		panic("invalid argument to Uint32N")
+	}
+	return uint32(uint64n(rng, uint64(n)))
+}
+
+// Returns a uniform value in [0, n).  Panics if n <= 0.
+func (rng *ShaRing) Int64N(n int64) int64 {
+	if n <= 0 {
+		panic("invalid argument to Int64N")
+	}
+	return int64(uint64n(rng, uint64(n)))
+}
+
+// Returns a uniform value in [0, n).  Panics if n <= 0.
+func (rng *ShaRing) Int32N(n int32) int32 {
+	if n <= 0 {
+		panic("invalid argument to Int32N")
+	}
+	return int32(uint64n(rng, uint64(n)))
+}
+
+// Returns a uniform value in [0, n).  Panics if n <= 0.
+func (rng *ShaRing) IntN(n int) int {
+	if n <= 0 {
+		panic("invalid argument to IntN")
+	}
+	return int(uint64n(rng, uint64(n)))
+}
+
+// Returns a uniform value in [lo, hi).  Panics if lo >= hi.
+func (rng *ShaRing) Int64Range(lo, hi int64) int64 {
+	if lo >= hi {
+		panic("invalid argument to Int64Range")
+	}
+	return int64range(rng, lo, hi)
+}
+
+// Returns a uniform value in [lo, hi).  Panics if lo >= hi.
+func (rng *ShaRing) IntRange(lo, hi int) int {
+	if lo >= hi {
+		panic("invalid argument to IntRange")
+	}
+	return int(int64range(rng, int64(lo), int64(hi)))
+}
+
+// Returns a uniform value in [lo, hi).  Panics if lo >= hi.
+func (rng *ShaRing) Uint64Range(lo, hi uint64) uint64 {
+	if lo >= hi {
+		panic("invalid argument to Uint64Range")
+	}
+	return lo + uint64n(rng, hi-lo)
+}
+
+// Returns a uniform value in [0, n) for n > 0, using Lemire's multiply-shift
+// method with rejection ("Fast Random Integer Generation in an Interval", 2019):
+//
+//	x := src.Uint64()
+//	hi, lo := 128-bit product x * n, split into its upper and lower 64 bits
+//	if lo < n:
+//	    threshold := (2^64 - n) mod n     // equal to 2^64 mod n
+//	    while lo < threshold:
+//	        x = src.Uint64()
+//	        hi, lo = x * n
+//	return hi
+//
+// Each iteration consumes exactly one Uint64() (eight bytes of a ShaRing's
+// stream) and the first is always accepted when n is a power of two.  Ports to
+// other languages will produce identical values from an identical sequence of
+// words as long as the product and comparisons use unsigned 64-bit arithmetic.
+// The expected number of words consumed is less than 2 for every n, and very
+// close to 1 unless n is near 2^64.
+func uint64n(src Source, n uint64) uint64 {
+	hi, lo := bits.Mul64(src.Uint64(), n)
+	if lo < n {
+		threshold := -n % n
+		for lo < threshold {
+			hi, lo = bits.Mul64(src.Uint64(), n)
+		}
+	}
+	return hi
+}
+
+// Returns a uniform value in [lo, hi) for lo < hi.  The width of the interval
+// is computed as an unsigned 64-bit difference (which cannot overflow), drawn
+// with uint64n(), and added back to lo with two's complement wrapping.
+func int64range(src Source, lo, hi int64) int64 {
+	return lo + int64(uint64n(src, uint64(hi)-uint64(lo)))
+}
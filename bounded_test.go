@@ -0,0 +1,122 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/bounded_test.go
+
+package gorng_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+func Test_BoundedRanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		lo, hi int64
+	}{
+		{"die", 1, 7},
+		{"negative", -10, -5},
+		{"straddle", -3, 3},
+		{"single", 42, 43},
+		{"wide", math.MinInt64, math.MaxInt64},
+	}
+	rng := gorng.NewGenerator([]byte("bounded"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 1000 {
+				value := rng.Int64Range(tt.lo, tt.hi)
+				if value < tt.lo || value >= tt.hi {
+					t.Fatalf("Int64Range(%d, %d) = %d", tt.lo, tt.hi, value)
+				}
+			}
+		})
+	}
+}
+
+// The ShaRing methods and Rand methods consume the stream identically.
+func Test_BoundedMatchesRand(t *testing.T) {
+	rng := gorng.NewGenerator([]byte("match"))
+	r := gorng.NewRand(gorng.NewGenerator([]byte("match")))
+	for i := range 1000 {
+		n := uint64(i)*0x9E3779B97F4A7C15 | 1
+		if got, want := rng.Uint64N(n), r.Uint64N(n); got != want {
+			t.Fatalf("Uint64N(%d) = %d, Rand gave %d", n, got, want)
+		}
+		if got, want := rng.IntRange(-i, i+1), r.IntRange(-i, i+1); got != want {
+			t.Fatalf("IntRange(%d, %d) = %d, Rand gave %d", -i, i+1, got, want)
+		}
+	}
+}
+
+// The rejection step of uint64n(), checked word by word.  For n = 2^64 * 2/3
+// (rounded up to odd) the threshold 2^64 mod n is 2^64 - n, so a word whose low
+// product is below it must be rejected and the next word used instead.
+func Test_BoundedRejection(t *testing.T) {
+	n := uint64(math.MaxUint64/3*2 + 1)
+	threshold := -n // 2^64 - n, as n > 2^63
+
+	// 0 * n has a low product of 0 < threshold, then 2^63 * n = (n >> 1, 2^63).
+	src := &sequenceSource{values: []uint64{0, 1 << 63, 42}}
+	if got, want := gorng.NewRand(src).Uint64N(n), n>>1; got != want {
+		t.Errorf("Uint64N(%#x) = %#x, want %#x from the second word", n, got, want)
+	}
+	if src.index != 2 {
+		t.Errorf("Uint64N(%#x) consumed %d words, want 2", n, src.index)
+	}
+	if 1<<63 < threshold {
+		t.Fatalf("second word's low product is below the threshold %#x", threshold)
+	}
+
+	// A low product of n itself is not below n, so the first word is accepted.
+	src = &sequenceSource{values: []uint64{1, 42}}
+	if got := gorng.NewRand(src).Uint64N(n); got != 0 || src.index != 1 {
+		t.Errorf("Uint64N(%#x) = %#x after %d words, want 0 after 1", n, got, src.index)
+	}
+
+	// Powers of two have a threshold of 0 and never reject, even for a word of 0.
+	for _, n := range []uint64{1, 8, 1 << 63} {
+		src := &sequenceSource{values: []uint64{0, 42}}
+		if got := gorng.NewRand(src).Uint64N(n); got != 0 || src.index != 1 {
+			t.Errorf("Uint64N(%#x) = %#x after %d words, want 0 after 1", n, got, src.index)
+		}
+		src = &sequenceSource{values: []uint64{math.MaxUint64, 42}}
+		if got := gorng.NewRand(src).Uint64N(n); got != n-1 || src.index != 1 {
+			t.Errorf("Uint64N(%#x) = %#x after %d words, want %#x after 1",
+				n, got, src.index, n-1)
+		}
+	}
+
+	// n == 0 panics without consuming any words.
+	src = &sequenceSource{values: []uint64{42}}
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Uint64N(0) did not panic")
+			}
+		}()
+		gorng.NewRand(src).Uint64N(0)
+	}()
+	if src.index != 0 {
+		t.Errorf("Uint64N(0) consumed %d words", src.index)
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/cards/card.go
+
+// Decks of playing cards (or any other kind of card) with replayable shuffles.
+//
+// A Deck is shuffled by gorng.Rand's Fisher-Yates Shuffle(), so the same seed
+// always gives the same order, and each shuffle can be exported as the index of
+// its permutation (see PermutationIndex) for reproducing or auditing a deal.
+package cards
+
+// One of the four French suits.
+type Suit uint8
+
+const (
+	Clubs Suit = iota
+	Diamonds
+	Hearts
+	Spades
+)
+
+func (suit Suit) String() string {
+	if suit > Spades {
+		return "?"
+	}
+	return string("cdhs"[suit])
+}
+
+// The rank of a card, from Ace (1) to King (13), or Joker (0).
+type Rank uint8
+
+const (
+	Joker Rank = iota
+	Ace
+	Two
+	Three
+	Four
+	Five
+	Six
+	Seven
+	Eight
+	Nine
+	Ten
+	Jack
+	Queen
+	King
+)
+
+func (rank Rank) String() string {
+	if rank > King {
+		return "?"
+	}
+	return string("*A23456789TJQK"[rank])
+}
+
+// A standard playing card.
+type Card struct {
+	Rank Rank
+	Suit Suit
+}
+
+// The card in short notation, rank then suit as in "As", "Td" or "2c", and "*"
+// for a joker.
+func (card Card) String() string {
+	if card.Rank == Joker {
+		return "*"
+	}
+	return card.Rank.String() + card.Suit.String()
+}
+
+// Returns the 52 cards of a standard deck in a fixed order: by suit (clubs,
+// diamonds, hearts then spades) and within each suit from ace to king.
+func Standard() []Card {
+	cards := make([]Card, 0, 52)
+	for suit := Clubs; suit <= Spades; suit++ {
+		for rank := Ace; rank <= King; rank++ {
+			cards = append(cards, Card{rank, suit})
+		}
+	}
+	return cards
+}
+
+// Returns the standard 52 cards followed by the provided number of jokers.
+func StandardWithJokers(jokers int) []Card {
+	cards := Standard()
+	for range jokers {
+		cards = append(cards, Card{Rank: Joker})
+	}
+	return cards
+}
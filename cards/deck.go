@@ -0,0 +1,162 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/cards/deck.go
+
+package cards
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// An ordered pile of cards of any type, with position 0 at the top.  Cards
+// that are drawn, dealt or burned leave the deck until Reset().
+type Deck[T any] struct {
+	cards  []T
+	burned []T
+
+	// The cards as the deck was created, for Reset().
+	initial []T
+}
+
+var ErrNotEnoughCards = errors.New("cards: not enough cards in the deck")
+
+// Creates a deck with a copy of the cards, the first of them on top.
+func NewDeck[T any](cards []T) *Deck[T] {
+	return NewShoe(1, cards)
+}
+
+// Creates a shoe of several copies of the cards, one after another, as used for
+// multi-deck games like blackjack.  Panics if decks < 1.
+func NewShoe[T any](decks int, cards []T) *Deck[T] {
+	if decks < 1 {
+		panic("invalid argument to NewShoe")
+	}
+	initial := make([]T, 0, decks*len(cards))
+	for range decks {
+		initial = append(initial, cards...)
+	}
+	deck := &Deck[T]{initial: initial}
+	deck.Reset()
+	return deck
+}
+
+// Returns all cards, including burned and dealt ones, to the deck in their
+// initial order.
+func (deck *Deck[T]) Reset() {
+	deck.cards = append(deck.cards[:0], deck.initial...)
+	deck.burned = deck.burned[:0]
+}
+
+// The number of cards remaining in the deck.
+func (deck *Deck[T]) Len() int {
+	return len(deck.cards)
+}
+
+// A copy of the remaining cards, from the top of the deck down.
+func (deck *Deck[T]) Cards() []T {
+	return append([]T(nil), deck.cards...)
+}
+
+// A copy of the cards that were burned since the last Reset(), in order.
+func (deck *Deck[T]) Burned() []T {
+	return append([]T(nil), deck.burned...)
+}
+
+// Shuffles the remaining cards with gorng.Rand's Shuffle(), consuming Len() - 1
+// bounded values from the source, and returns the index of the permutation that
+// was applied (see PermutationIndex).  Apply(index) to the deck in its earlier
+// order reproduces the shuffle.
+func (deck *Deck[T]) Shuffle(src gorng.Source) *big.Int {
+	perm := gorng.NewRand(src).Perm(len(deck.cards))
+	deck.permute(perm)
+	return PermutationIndex(perm)
+}
+
+// Rearranges the remaining cards by the permutation with this index, as
+// returned by Shuffle() for a deck of the same size.
+func (deck *Deck[T]) Apply(index *big.Int) error {
+	perm, err := PermutationFromIndex(len(deck.cards), index)
+	if err != nil {
+		return err
+	}
+	deck.permute(perm)
+	return nil
+}
+
+// Moves the card at position perm[i] to position i, for each i.
+func (deck *Deck[T]) permute(perm []int) {
+	previous := deck.Cards()
+	for i, from := range perm {
+		deck.cards[i] = previous[from]
+	}
+}
+
+// Removes and returns the top n cards, the top card first.
+func (deck *Deck[T]) Draw(n int) ([]T, error) {
+	if n < 0 || n > len(deck.cards) {
+		return nil, ErrNotEnoughCards
+	}
+	drawn := append([]T(nil), deck.cards[:n]...)
+	deck.cards = deck.cards[n:]
+	return drawn, nil
+}
+
+// Deals count cards to each of the hands, one card at a time to each hand in
+// turn, as at a card table.
+func (deck *Deck[T]) Deal(hands, count int) ([][]T, error) {
+	if hands < 0 || count < 0 || hands*count > len(deck.cards) {
+		return nil, ErrNotEnoughCards
+	}
+	dealt := make([][]T, hands)
+	for i := range dealt {
+		dealt[i] = make([]T, count)
+	}
+	for round := 0; round < count; round++ {
+		for hand := range dealt {
+			dealt[hand][round] = deck.cards[round*hands+hand]
+		}
+	}
+	deck.cards = deck.cards[hands*count:]
+	return dealt, nil
+}
+
+// Discards the top n cards face down; they are kept in Burned().
+func (deck *Deck[T]) Burn(n int) error {
+	burned, err := deck.Draw(n)
+	if err == nil {
+		deck.burned = append(deck.burned, burned...)
+	}
+	return err
+}
+
+// Moves the top n cards, as a block, to the bottom of the deck.
+func (deck *Deck[T]) Cut(n int) error {
+	if n < 0 || n > len(deck.cards) {
+		return ErrNotEnoughCards
+	}
+	cut := make([]T, 0, len(deck.cards))
+	cut = append(cut, deck.cards[n:]...)
+	deck.cards = append(cut, deck.cards[:n]...)
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/cards/deck_test.go
+
+package cards_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/cards"
+)
+
+func Test_Standard(t *testing.T) {
+	deck := cards.Standard()
+	if len(deck) != 52 {
+		t.Fatalf("len(Standard()) = %d", len(deck))
+	}
+	seen := make(map[string]bool)
+	for _, card := range deck {
+		seen[card.String()] = true
+	}
+	if len(seen) != 52 {
+		t.Errorf("Standard() has %d distinct cards", len(seen))
+	}
+	if first, last := deck[0].String(), deck[51].String(); first != "Ac" || last != "Ks" {
+		t.Errorf("Standard() runs from %s to %s, want Ac to Ks", first, last)
+	}
+	if jokers := cards.StandardWithJokers(2); len(jokers) != 54 || jokers[53].String() != "*" {
+		t.Errorf("StandardWithJokers(2) = %v", jokers[52:])
+	}
+}
+
+// The shuffle is gorng.Rand's Perm() applied to the deck.
+func Test_ShuffleReplay(t *testing.T) {
+	deck := cards.NewDeck(cards.Standard())
+	deck.Shuffle(gorng.NewSourceFromString("table 7"))
+
+	perm := gorng.NewRand(gorng.NewSourceFromString("table 7")).Perm(52)
+	standard := cards.Standard()
+	for i, card := range deck.Cards() {
+		if card != standard[perm[i]] {
+			t.Fatalf("position %d = %s, want %s", i, card, standard[perm[i]])
+		}
+	}
+
+	again := cards.NewDeck(cards.Standard())
+	again.Shuffle(gorng.NewSourceFromString("table 7"))
+	if !reflect.DeepEqual(deck.Cards(), again.Cards()) {
+		t.Error("the same seed shuffled differently")
+	}
+}
+
+func Test_ShuffleIndex(t *testing.T) {
+	deck := cards.NewDeck(cards.Standard())
+	index := deck.Shuffle(gorng.NewSourceFromString("audit"))
+	if index.BitLen() > 226 {
+		t.Errorf("index of a 52-card shuffle has %d bits", index.BitLen())
+	}
+
+	audit := cards.NewDeck(cards.Standard())
+	if err := audit.Apply(index); err != nil {
+		t.Fatalf("Apply() error: %s", err)
+	}
+	if !reflect.DeepEqual(audit.Cards(), deck.Cards()) {
+		t.Error("applying the shuffle's index gave a different order")
+	}
+
+	// A second shuffle is relative to the deck's order at the time.
+	index = deck.Shuffle(gorng.NewSourceFromString("second"))
+	if err := audit.Apply(index); err != nil || !reflect.DeepEqual(audit.Cards(), deck.Cards()) {
+		t.Error("applying the second shuffle's index gave a different order")
+	}
+}
+
+func Test_DealDrawBurnCut(t *testing.T) {
+	deck := cards.NewDeck([]int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+	if err := deck.Cut(3); err != nil {
+		t.Fatalf("Cut(3) error: %s", err)
+	}
+	if got := deck.Cards(); !reflect.DeepEqual(got, []int{3, 4, 5, 6, 7, 8, 9, 0, 1, 2}) {
+		t.Errorf("after Cut(3), Cards() = %v", got)
+	}
+	if err := deck.Burn(1); err != nil || !reflect.DeepEqual(deck.Burned(), []int{3}) {
+		t.Errorf("Burn(1) = %v, Burned() = %v", err, deck.Burned())
+	}
+
+	hands, err := deck.Deal(3, 2)
+	if err != nil {
+		t.Fatalf("Deal(3, 2) error: %s", err)
+	}
+	if want := [][]int{{4, 7}, {5, 8}, {6, 9}}; !reflect.DeepEqual(hands, want) {
+		t.Errorf("Deal(3, 2) = %v, want %v", hands, want)
+	}
+	if drawn, err := deck.Draw(2); err != nil || !reflect.DeepEqual(drawn, []int{0, 1}) {
+		t.Errorf("Draw(2) = %v, %v", drawn, err)
+	}
+
+	if _, err := deck.Draw(2); err != cards.ErrNotEnoughCards {
+		t.Errorf("Draw() past the bottom: error %v", err)
+	}
+	if _, err := deck.Deal(2, 1); err != cards.ErrNotEnoughCards {
+		t.Errorf("Deal() past the bottom: error %v", err)
+	}
+	if deck.Len() != 1 {
+		t.Errorf("Len() = %d, want 1 after failed draws", deck.Len())
+	}
+
+	deck.Reset()
+	if deck.Len() != 10 || len(deck.Burned()) != 0 || deck.Cards()[0] != 0 {
+		t.Errorf("after Reset(), Cards() = %v, Burned() = %v", deck.Cards(), deck.Burned())
+	}
+}
+
+func Test_Shoe(t *testing.T) {
+	shoe := cards.NewShoe(6, cards.Standard())
+	if shoe.Len() != 312 {
+		t.Fatalf("Len() of a six-deck shoe = %d", shoe.Len())
+	}
+	shoe.Shuffle(gorng.NewSourceFromString("blackjack"))
+	counts := make(map[cards.Card]int)
+	for _, card := range shoe.Cards() {
+		counts[card]++
+	}
+	for card, count := range counts {
+		if count != 6 {
+			t.Errorf("%s appears %d times", card, count)
+		}
+	}
+}
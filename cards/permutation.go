@@ -0,0 +1,92 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/cards/permutation.go
+
+package cards
+
+import (
+	"errors"
+	"math/big"
+)
+
+// Permutations of n positions are numbered from 0 to n! - 1 by their Lehmer
+// code: perm[i] is the position a card came from, c[i] is the number of j > i
+// with perm[j] < perm[i], and the index is the sum of c[i] * (n-1-i)!.  The
+// identity is 0 and reversing the deck gives n! - 1, and a shuffle of a 52-card
+// deck fits in 226 bits, or 57 hexadecimal digits.
+
+var ErrInvalidPermutation = errors.New("cards: invalid permutation")
+
+// Returns the index of perm, which must be a permutation of [0, len(perm)).
+// Panics if it isn't one.
+func PermutationIndex(perm []int) *big.Int {
+	n := len(perm)
+	seen := make([]bool, n)
+	index := new(big.Int)
+	for i, from := range perm {
+		if from < 0 || from >= n || seen[from] {
+			panic("invalid argument to PermutationIndex")
+		}
+		seen[from] = true
+		smaller := 0
+		for _, later := range perm[i+1:] {
+			if later < from {
+				smaller++
+			}
+		}
+		// Horner's rule: index = (index * (n-i)) + c[i], as (n-1-i)! divides
+		// each of the earlier factorials.
+		index.Mul(index, big.NewInt(int64(n-i)))
+		index.Add(index, big.NewInt(int64(smaller)))
+	}
+	return index
+}
+
+// Returns the permutation of [0, n) with this index, the inverse of
+// PermutationIndex().
+func PermutationFromIndex(n int, index *big.Int) ([]int, error) {
+	if n < 0 || index.Sign() < 0 {
+		return nil, ErrInvalidPermutation
+	}
+	// Peel off the digits c[n-1], ..., c[0] of the factorial number system.
+	digits := make([]int, n)
+	rest := new(big.Int).Set(index)
+	radix, digit := new(big.Int), new(big.Int)
+	for i := n - 1; i >= 0; i-- {
+		radix.SetInt64(int64(n - i))
+		rest.QuoRem(rest, radix, digit)
+		digits[i] = int(digit.Int64())
+	}
+	if rest.Sign() != 0 {
+		return nil, ErrInvalidPermutation
+	}
+
+	remaining := make([]int, n)
+	for i := range remaining {
+		remaining[i] = i
+	}
+	perm := make([]int, n)
+	for i, smaller := range digits {
+		perm[i] = remaining[smaller]
+		remaining = append(remaining[:smaller], remaining[smaller+1:]...)
+	}
+	return perm, nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/cards/permutation_test.go
+
+package cards_test
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng/cards"
+)
+
+func Test_PermutationIndex(t *testing.T) {
+	tests := []struct {
+		perm  []int
+		index int64
+	}{
+		{[]int{}, 0},
+		{[]int{0}, 0},
+		{[]int{0, 1, 2}, 0},
+		{[]int{0, 2, 1}, 1},
+		{[]int{1, 0, 2}, 2},
+		{[]int{1, 2, 0}, 3},
+		{[]int{2, 0, 1}, 4},
+		{[]int{2, 1, 0}, 5},
+		{[]int{3, 2, 1, 0}, 23},
+		{[]int{1, 0, 3, 2}, 7},
+	}
+	for _, tt := range tests {
+		if got := cards.PermutationIndex(tt.perm); got.Int64() != tt.index {
+			t.Errorf("PermutationIndex(%v) = %s, want %d", tt.perm, got, tt.index)
+		}
+		perm, err := cards.PermutationFromIndex(len(tt.perm), big.NewInt(tt.index))
+		if err != nil || !reflect.DeepEqual(perm, tt.perm) {
+			t.Errorf("PermutationFromIndex(%d, %d) = %v, %v", len(tt.perm), tt.index, perm, err)
+		}
+	}
+}
+
+func Test_PermutationIndexLarge(t *testing.T) {
+	reversed := make([]int, 52)
+	for i := range reversed {
+		reversed[i] = 51 - i
+	}
+	factorial := new(big.Int).MulRange(1, 52)
+	last := new(big.Int).Sub(factorial, big.NewInt(1))
+	if got := cards.PermutationIndex(reversed); got.Cmp(last) != 0 {
+		t.Errorf("index of the reversed deck = %s, want 52! - 1", got)
+	}
+	if perm, err := cards.PermutationFromIndex(52, last); err != nil || !reflect.DeepEqual(perm, reversed) {
+		t.Errorf("PermutationFromIndex(52, 52! - 1) = %v, %v", perm, err)
+	}
+
+	if _, err := cards.PermutationFromIndex(52, factorial); err != cards.ErrInvalidPermutation {
+		t.Errorf("PermutationFromIndex(52, 52!) error = %v", err)
+	}
+	if _, err := cards.PermutationFromIndex(3, big.NewInt(-1)); err != cards.ErrInvalidPermutation {
+		t.Errorf("PermutationFromIndex(3, -1) error = %v", err)
+	}
+}
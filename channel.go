@@ -0,0 +1,79 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/channel.go
+
+package gorng
+
+import "github.com/SymbolNotFound/gorng/safe"
+
+// Returns a channel that produces values of the indicated number of bits, in
+// the same format as NextBits().  Reading from the channel is goroutine-safe.
+//
+// Channels of different sizes can coexist on the same generator; they take
+// turns drawing from the generator's stream, so the order in which values are
+// delivered depends on scheduling.  Requesting a size that already has an open
+// channel returns that same channel.  While any channel is open, the other
+// methods of this generator should not be called directly from goroutines that
+// aren't otherwise synchronized with the channel readers.
+func (rng *ShaRing) Channel(bits int) <-chan []byte {
+	rng.mu.Lock()
+	defer rng.mu.Unlock()
+
+	if existing, found := rng.channels[bits]; found {
+		return existing.Channel()
+	}
+	if rng.channels == nil {
+		rng.channels = make(map[int]safe.SafeRandom)
+	}
+	saferandom := safe.New(lockedRing{rng}, bits)
+	rng.channels[bits] = saferandom
+	return saferandom.Channel()
+}
+
+// Stops all channels produced by this generator.  Each channel is closed after
+// its producer stops, so readers will observe a nil value after this call.
+func (rng *ShaRing) Close() {
+	rng.mu.Lock()
+	defer rng.mu.Unlock()
+
+	for _, saferandom := range rng.channels {
+		saferandom.Close()
+	}
+	clear(rng.channels)
+}
+
+// Holds the generator's lock for each draw, so that the producers of channels
+// of different sizes can share the same generator.
+type lockedRing struct {
+	rng *ShaRing
+}
+
+func (locked lockedRing) Uint64() uint64 {
+	locked.rng.mu.Lock()
+	defer locked.rng.mu.Unlock()
+	return locked.rng.Uint64()
+}
+
+func (locked lockedRing) NextBits(bits int) []byte {
+	locked.rng.mu.Lock()
+	defer locked.rng.mu.Unlock()
+	return locked.rng.NextBits(bits)
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/cmd/ent/main.go
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/quality"
+	"github.com/SymbolNotFound/gorng/sha256"
+)
+
+// Runs the statistical tests of the quality package on the output of a seeded
+// generator, or on the contents of a file, and prints each test's statistic and
+// p-value.  Exits with status 1 if any test has a p-value below --alpha, so it
+// can run in CI; with a fixed seed the results are the same on every run.
+// With --bound, every bounded-int method of ShaRing (Uint64N through
+// Uint64Range) also gets a chi-square test for uniformity over that many values.
+//
+// Example usage:
+//   ent --seed "puzzle 42" --bytes 4000000
+//   ent --hasher sha256 --bound 52
+//   head -c 1000000 /dev/urandom | ent --file -
+
+const MAX_BOUND = 1 << 24
+
+func main() {
+	filename := flag.String("file", "", "path to a file to test, - for stdin")
+	seed := flag.String("seed", "gorng", "seed string for the generator (NewSourceFromString)")
+	hasher := flag.String("hasher", "sha1", "generator to test: sha1, sha256 or counter")
+	size := flag.Int("bytes", 1<<20, "number of bytes to generate")
+	bound := flag.Uint64("bound", 0,
+		"also test every bounded-int helper over bound values for uniformity, up to 2^24, if non-zero")
+	alpha := flag.Float64("alpha", 0.001, "significance level for failing a test")
+
+	flag.Parse()
+
+	// quality.Uniform() skips bounds above MAX_BOUND anyway.
+	if *bound > MAX_BOUND {
+		usageError(fmt.Sprintf("Expected --bound in 1..%d, or 0 to skip.", MAX_BOUND))
+	}
+	if *size < 0 {
+		usageError("Expected a non-negative number of --bytes.")
+	}
+
+	var data []byte
+	var rng *gorng.ShaRing
+	var err error
+	if len(*filename) > 0 {
+		data, err = readInput(*filename)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("input %s, %d bytes\n\n", *filename, len(data))
+	} else {
+		switch *hasher {
+		case "sha1":
+			rng = gorng.NewSourceFromString(*seed)
+		case "sha256":
+			rng = gorng.NewGeneratorWith(sha256.New(), []byte(*seed))
+		case "counter":
+			rng = gorng.NewCounter([]byte(*seed)).ShaRing
+		default:
+			log.Fatalf("unknown hasher %q, expected sha1, sha256 or counter", *hasher)
+		}
+		data = make([]byte, *size)
+		rng.Read(data)
+		fmt.Printf("%s generator, seed %q, %d bytes\n\n", *hasher, *seed, len(data))
+	}
+
+	results := quality.Battery(data)
+	if *bound > 0 {
+		if rng == nil {
+			log.Fatal("--bound needs a generator, not --file")
+		}
+		// 100 values per possible value, except for large bounds where that would
+		// take more than 512 MiB; quality.Uniform() needs at least 5.
+		values := make([]uint64, max(5*(*bound), min(100*(*bound), 1<<26)))
+		for _, helper := range boundedHelpers(rng, *bound) {
+			for i := range values {
+				values[i] = helper.draw()
+			}
+			result := quality.Uniform(values, *bound)
+			result.Name = "uniform " + helper.name
+			results = append(results, result)
+		}
+	}
+
+	failed := false
+	fmt.Printf("%-48s %14s %10s\n", "test", "statistic", "p-value")
+	for _, result := range results {
+		verdict := "pass"
+		if result.Skipped() {
+			verdict = "skipped, input too short"
+		} else if !result.Pass(*alpha) {
+			verdict = "FAIL"
+			failed = true
+		}
+		fmt.Printf("%-48s %14.4f %10.6f  %s\n", result.Name, result.Statistic, result.PValue, verdict)
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func usageError(message string) {
+	fmt.Println(message + "  Quitting.")
+	fmt.Println()
+	flag.Usage()
+	os.Exit(2)
+}
+
+// A bounded-int method of ShaRing, with its result shifted into [0, bound).
+type boundedHelper struct {
+	name string
+	draw func() uint64
+}
+
+// Every bounded-int method, for a bound of at most MAX_BOUND.  The ranges are
+// centered on zero (or on 2^63 for unsigned values) so that negative values and
+// large offsets are covered too.
+func boundedHelpers(rng *gorng.ShaRing, bound uint64) []boundedHelper {
+	n := int64(bound)
+	lo := -n / 2
+	ulo := uint64(1<<63) - bound/2
+	return []boundedHelper{
+		{fmt.Sprintf("Uint64N(%d)", bound),
+			func() uint64 { return rng.Uint64N(bound) }},
+		{fmt.Sprintf("Uint32N(%d)", bound),
+			func() uint64 { return uint64(rng.Uint32N(uint32(bound))) }},
+		{fmt.Sprintf("Int64N(%d)", n),
+			func() uint64 { return uint64(rng.Int64N(n)) }},
+		{fmt.Sprintf("Int32N(%d)", n),
+			func() uint64 { return uint64(rng.Int32N(int32(n))) }},
+		{fmt.Sprintf("IntN(%d)", n),
+			func() uint64 { return uint64(rng.IntN(int(n))) }},
+		{fmt.Sprintf("Int64Range(%d, %d)", lo, lo+n),
+			func() uint64 { return uint64(rng.Int64Range(lo, lo+n) - lo) }},
+		{fmt.Sprintf("IntRange(%d, %d)", lo, lo+n),
+			func() uint64 { return uint64(rng.IntRange(int(lo), int(lo+n)) - int(lo)) }},
+		{fmt.Sprintf("Uint64Range(2^63-%d, 2^63+%d)", bound/2, bound-bound/2),
+			func() uint64 { return rng.Uint64Range(ulo, ulo+bound) - ulo }},
+	}
+}
+
+func readInput(filename string) ([]byte, error) {
+	if filename == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(filename)
+}
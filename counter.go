@@ -0,0 +1,173 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/counter.go
+
+package gorng
+
+import (
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/SymbolNotFound/gorng/sha1"
+)
+
+// A generator whose stream is the concatenation of the digests
+//
+//	SHA-1(seed || counter)
+//
+// for counter = 0, 1, 2, ... written as a big-endian uint64.  Each digest is a
+// standard SHA-1 hash, independent of the ones before it, so any position in
+// the stream can be reached in constant time.  All of the ShaRing methods are
+// available and draw from the stream in the same way, so values produced after
+// a Seek() are identical to the ones produced by reading up to that point.
+//
+// Mix(data) appends data to the seed, so the whole stream becomes that of
+// NewCounter(seed || data): At() returns the new stream's values for every
+// position, including ones already read, while Position() is unchanged and
+// reading continues from the same byte of the new stream.  Reseed(seed) makes
+// the generator the same as NewCounter(seed), at position 0.
+type CounterRing struct {
+	*ShaRing
+	counter *counterHasher
+}
+
+// Creates a counter-mode generator for the provided seed.
+func NewCounter(seed []byte) *CounterRing {
+	counter := newCounterHasher()
+	counter.Write(seed)
+	return &CounterRing{newRing(counter), counter}
+}
+
+// Positions the generator so that the next Uint64() returns At(n), that is, the
+// stream continues from byte 8n.  Any n is valid; the byte position is computed
+// with 128-bit arithmetic so it does not wrap.
+func (rng *CounterRing) Seek(n uint64) {
+	block, offset := bytePosition(n)
+	rng.counter.counter = block
+	rng.offset = offset
+	rng.digest = nil
+	if rng.offset != 0 {
+		rng.digest = rng.counter.Hash()
+	}
+}
+
+// Returns the index of the next 64-bit output, the inverse of Seek().  If a
+// narrower method has consumed only part of an output, this rounds down.  Reading
+// past output 2^64 - 1 wraps the position around to 0.
+func (rng *CounterRing) Position() uint64 {
+	hi, lo := bits.Mul64(rng.counter.counter, sha1.DIGEST_BYTES)
+	if rng.offset != 0 {
+		var borrow uint64
+		lo, borrow = bits.Sub64(lo, uint64(sha1.DIGEST_BYTES-rng.offset), 0)
+		hi -= borrow
+	}
+	return hi<<61 | lo>>3
+}
+
+// Returns the n'th 64-bit output of the stream (from byte 8n, big-endian)
+// without affecting the generator's position.
+func (rng *CounterRing) At(n uint64) uint64 {
+	var bytes [8]byte
+	block, offset := bytePosition(n)
+	count := copy(bytes[:], rng.counter.block(block).Bytes()[offset:])
+	if count < len(bytes) {
+		copy(bytes[count:], rng.counter.block(block+1).Bytes())
+	}
+	return binary.BigEndian.Uint64(bytes[:])
+}
+
+// Returns the block and the offset within it of byte 8n of the stream.  Since
+// 8n < 2^67 the quotient by the digest size always fits in a uint64.
+func bytePosition(n uint64) (uint64, int) {
+	hi, lo := bits.Mul64(n, 8)
+	block, offset := bits.Div64(hi, lo, sha1.DIGEST_BYTES)
+	return block, int(offset)
+}
+
+// Implements Hasher, where Write() appends to the seed and each Hash()
+// returns the next block of the counter-mode stream.
+type counterHasher struct {
+	seed    []byte
+	counter uint64
+
+	// SHA-1 state after writing the seed, so it is only hashed once.
+	prefix []byte
+	work   sha1.Hasher
+}
+
+func newCounterHasher() *counterHasher {
+	counter := &counterHasher{work: sha1.New()}
+	counter.Reset()
+	return counter
+}
+
+func (counter *counterHasher) Write(message []byte) (int, error) {
+	counter.seed = append(counter.seed, message...)
+	counter.work.Reset()
+	counter.work.Write(counter.seed)
+	counter.prefix, _ = marshalHasher(counter.work)
+	return len(message), nil
+}
+
+func (counter *counterHasher) Hash() Digest {
+	digest := counter.block(counter.counter)
+	counter.counter++
+	return digest
+}
+
+// Forgets the seed and returns the counter to zero.
+func (counter *counterHasher) Reset() {
+	counter.seed = counter.seed[:0]
+	counter.counter = 0
+	counter.work.Reset()
+	counter.prefix, _ = marshalHasher(counter.work)
+}
+
+// Computes SHA-1(seed || index) without changing the counter.
+func (counter *counterHasher) block(index uint64) Digest {
+	var bytes [8]byte
+	binary.BigEndian.PutUint64(bytes[:], index)
+	counter.work.(encoding.BinaryUnmarshaler).UnmarshalBinary(counter.prefix)
+	counter.work.Write(bytes[:])
+	return counter.work.Hash()
+}
+
+// The counter's state is "ctr1" | counter (8 bytes) | seed.
+const counterMagic = "ctr1"
+
+func (counter *counterHasher) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(counterMagic)+8+len(counter.seed))
+	data = append(data, counterMagic...)
+	data = binary.BigEndian.AppendUint64(data, counter.counter)
+	return append(data, counter.seed...), nil
+}
+
+func (counter *counterHasher) UnmarshalBinary(data []byte) error {
+	if len(data) < len(counterMagic)+8 || string(data[:len(counterMagic)]) != counterMagic {
+		return errors.New("gorng: invalid counter state")
+	}
+	counter.Reset()
+	counter.Write(data[len(counterMagic)+8:])
+	counter.counter = binary.BigEndian.Uint64(data[len(counterMagic):])
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/counter_test.go
+
+package gorng_test
+
+import (
+	"bytes"
+	gosha1 "crypto/sha1" // for reference implementation
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// Each block is the standard SHA-1 of the seed and a big-endian counter.
+func Test_CounterBlocks(t *testing.T) {
+	seed := []byte("counter")
+	rng := gorng.NewCounter(seed)
+	for i := range byte(5) {
+		want := gosha1.Sum(append(bytes.Clone(seed), 0, 0, 0, 0, 0, 0, 0, i))
+		if got := rng.NextBits(8 * len(want)); !bytes.Equal(got, want[:]) {
+			t.Errorf("block %d\ngot:  %x\nwant: %x", i, got, want)
+		}
+	}
+}
+
+func Test_CounterRandomAccess(t *testing.T) {
+	rng := gorng.NewCounter([]byte("random access"))
+	stream := make([]uint64, 100)
+	for i := range stream {
+		if position := rng.Position(); position != uint64(i) {
+			t.Fatalf("Position() = %d, want %d", position, i)
+		}
+		stream[i] = rng.Uint64()
+	}
+
+	for _, n := range []uint64{0, 1, 2, 3, 4, 5, 12, 37, 99, 50, 0} {
+		if got := rng.At(n); got != stream[n] {
+			t.Errorf("At(%d) = %x, want %x", n, got, stream[n])
+		}
+		rng.Seek(n)
+		if position := rng.Position(); position != n {
+			t.Errorf("after Seek(%d), Position() = %d", n, position)
+		}
+		if got := rng.Uint64(); got != stream[n] {
+			t.Errorf("after Seek(%d), Uint64() = %x, want %x", n, got, stream[n])
+		}
+	}
+
+	// Far positions don't require generating the values before them.
+	far := uint64(1) << 40
+	want := rng.At(far + 1)
+	rng.Seek(far)
+	rng.Uint64()
+	if got := rng.Uint64(); got != want {
+		t.Errorf("Uint64() after Seek(2^40) = %x, want %x", got, want)
+	}
+}
+
+// Positions whose byte offset 8n does not fit in a uint64.
+func Test_CounterFarPositions(t *testing.T) {
+	seed := []byte("far")
+	rng := gorng.NewCounter(seed)
+
+	// Byte 2^65 is at offset 12 of block floor(2^65 / 20).
+	block := binary.BigEndian.AppendUint64(bytes.Clone(seed), 1844674407370955161)
+	digest := gosha1.Sum(block)
+	want := binary.BigEndian.Uint64(digest[12:])
+	if got := rng.At(1 << 62); got != want {
+		t.Errorf("At(2^62) = %x, want %x", got, want)
+	}
+
+	for _, n := range []uint64{1 << 61, 1 << 62, math.MaxUint64 - 1, math.MaxUint64} {
+		want := rng.At(n)
+		rng.Seek(n)
+		if position := rng.Position(); position != n {
+			t.Errorf("after Seek(%#x), Position() = %#x", n, position)
+		}
+		if got := rng.Uint64(); got != want {
+			t.Errorf("after Seek(%#x), Uint64() = %x, want %x", n, got, want)
+		}
+	}
+	if position := rng.Position(); position != 0 {
+		t.Errorf("Position() after the last output = %#x, want 0", position)
+	}
+}
+
+func Test_CounterState(t *testing.T) {
+	rng := gorng.NewCounter([]byte("state"))
+	rng.Seek(1000)
+	rng.NextUint16()
+	bookmark, err := rng.Bookmark()
+	if err != nil {
+		t.Fatalf("Bookmark() error: %s", err)
+	}
+	want := rng.NextBits(8 * 50)
+
+	rng.Seek(3)
+	if err := rng.Restore(bookmark); err != nil {
+		t.Fatalf("Restore() error: %s", err)
+	}
+	if got := rng.NextBits(8 * 50); !bytes.Equal(got, want) {
+		t.Errorf("restored counter diverged\ngot:  %x\nwant: %x", got, want)
+	}
+
+	if err := rng.Rewind(); err != nil {
+		t.Fatalf("Rewind() error: %s", err)
+	}
+	if got, want := rng.Uint64(), rng.At(0); got != want {
+		t.Errorf("after Rewind(), Uint64() = %x, want %x", got, want)
+	}
+}
+
+// Mixing re-keys the whole stream but keeps the position, including partway
+// through a digest; reseeding starts over from the new seed.
+func Test_CounterMix(t *testing.T) {
+	rng := gorng.NewCounter([]byte("mix"))
+	for range 3 {
+		rng.Uint64()
+	}
+	rng.NextUint32()
+	before := rng.At(1)
+	rng.Mix([]byte("move"))
+
+	mixed := gorng.NewCounter([]byte("mixmove"))
+	if position := rng.Position(); position != 3 {
+		t.Errorf("Position() after Mix() = %d, want 3", position)
+	}
+	if got, want := rng.At(1), mixed.At(1); got != want || got == before {
+		t.Errorf("At(1) after Mix() = %x, want %x (was %x)", got, want, before)
+	}
+	mixed.Seek(3)
+	mixed.NextUint32()
+	if got, want := rng.NextBits(8*50), mixed.NextBits(8*50); !bytes.Equal(got, want) {
+		t.Errorf("stream after Mix()\ngot:  %x\nwant: %x", got, want)
+	}
+
+	rng.Reseed([]byte("fresh"))
+	if position := rng.Position(); position != 0 {
+		t.Errorf("Position() after Reseed() = %d, want 0", position)
+	}
+	fresh := gorng.NewCounter([]byte("fresh"))
+	if got, want := rng.At(7), fresh.At(7); got != want {
+		t.Errorf("At(7) after Reseed() = %x, want %x", got, want)
+	}
+	if got, want := rng.Uint64(), fresh.Uint64(); got != want {
+		t.Errorf("Uint64() after Reseed() = %x, want %x", got, want)
+	}
+}
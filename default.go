@@ -0,0 +1,163 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/default.go
+
+package gorng
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Name of the environment variable that, when set, overrides the seed of the
+// default generator.  The value is parsed as a uint64 in decimal, or in hex or
+// octal when prefixed with 0x or 0o, e.g. GORNG_SEED=0x1337.  An invalid value
+// is not silently replaced by a time-based seed: every use of the default
+// generator panics until SetDefaultSeed() provides a seed explicitly.
+const SEED_ENV = "GORNG_SEED"
+
+// The generator behind the package-level Random* functions.  It is created on
+// first use, seeded from SEED_ENV when present or the current time otherwise.
+var defaultRing struct {
+	init sync.Once
+	mu   sync.Mutex
+	seed uint64
+	rng  *ShaRing
+	err  error // from parsing SEED_ENV, rng is nil when set
+}
+
+// Replaces the default generator with one seeded by the provided value.  Values
+// drawn from the package-level functions after this call are reproducible from
+// the seed (assuming they are not being drawn concurrently).
+func SetDefaultSeed(seed uint64) {
+	defaultRing.init.Do(func() {})
+	defaultRing.mu.Lock()
+	defer defaultRing.mu.Unlock()
+	defaultRing.seed = seed
+	defaultRing.rng = newSeededDefault(seed)
+	defaultRing.err = nil
+}
+
+// Returns the seed of the default generator, which can be logged so that a
+// program run can be reproduced by passing it back via SEED_ENV.
+func DefaultSeed() uint64 {
+	lockDefault()
+	defer defaultRing.mu.Unlock()
+	return defaultRing.seed
+}
+
+func newSeededDefault(seed uint64) *ShaRing {
+	return NewSourceSeeded(seed)
+}
+
+// Initializes the default generator if necessary and returns it with its lock
+// held.  Callers are responsible for unlocking defaultRing.mu.
+//
+// Panics (without holding the lock) if SEED_ENV is invalid.  The error is kept
+// rather than raised inside the Once, so that a recovered panic is raised again
+// on the next call instead of leaving a nil generator behind.
+func lockDefault() *ShaRing {
+	defaultRing.init.Do(func() {
+		seed := uint64(time.Now().UnixNano())
+		if value, found := os.LookupEnv(SEED_ENV); found {
+			parsed, err := strconv.ParseUint(value, 0, 64)
+			if err != nil {
+				defaultRing.err = fmt.Errorf("gorng: invalid %s value %q: %w", SEED_ENV, value, err)
+				return
+			}
+			seed = parsed
+		}
+		defaultRing.seed = seed
+		defaultRing.rng = newSeededDefault(seed)
+	})
+	defaultRing.mu.Lock()
+	if err := defaultRing.err; err != nil {
+		defaultRing.mu.Unlock()
+		panic(err)
+	}
+	return defaultRing.rng
+}
+
+// Generates an arbitrary number of bits using the default generator, in the
+// same format as ShaRing.NextBits().
+func RandomBits(bits int) []byte {
+	rng := lockDefault()
+	defer defaultRing.mu.Unlock()
+	return rng.NextBits(bits)
+}
+
+// Generates count bytes using the default generator.
+func RandomBytes(count int) []byte {
+	rng := lockDefault()
+	defer defaultRing.mu.Unlock()
+	return rng.NextBits(8 * count)
+}
+
+func RandomUint8() uint8 {
+	rng := lockDefault()
+	defer defaultRing.mu.Unlock()
+	return rng.NextUint8()
+}
+
+func RandomUint16() uint16 {
+	rng := lockDefault()
+	defer defaultRing.mu.Unlock()
+	return rng.NextUint16()
+}
+
+func RandomUint32() uint32 {
+	rng := lockDefault()
+	defer defaultRing.mu.Unlock()
+	return rng.NextUint32()
+}
+
+func RandomUint64() uint64 {
+	rng := lockDefault()
+	defer defaultRing.mu.Unlock()
+	return rng.NextUint64()
+}
+
+func RandomInt8() int8 {
+	rng := lockDefault()
+	defer defaultRing.mu.Unlock()
+	return rng.NextInt8()
+}
+
+func RandomInt16() int16 {
+	rng := lockDefault()
+	defer defaultRing.mu.Unlock()
+	return rng.NextInt16()
+}
+
+func RandomInt32() int32 {
+	rng := lockDefault()
+	defer defaultRing.mu.Unlock()
+	return rng.NextInt32()
+}
+
+func RandomInt64() int64 {
+	rng := lockDefault()
+	defer defaultRing.mu.Unlock()
+	return rng.NextInt64()
+}
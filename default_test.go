@@ -0,0 +1,104 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/default_test.go
+
+package gorng_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+func Test_DefaultSeed(t *testing.T) {
+	gorng.SetDefaultSeed(1337)
+	if gorng.DefaultSeed() != 1337 {
+		t.Errorf("DefaultSeed() = %d, want 1337", gorng.DefaultSeed())
+	}
+	first := gorng.RandomBytes(64)
+	value := gorng.RandomInt32()
+
+	gorng.SetDefaultSeed(1337)
+	if again := gorng.RandomBytes(64); !bytes.Equal(first, again) {
+		t.Errorf("reseeding did not reproduce the sequence\ngot:  %x\nwant: %x",
+			again, first)
+	}
+	if again := gorng.RandomInt32(); again != value {
+		t.Errorf("RandomInt32() = %d, want %d", again, value)
+	}
+
+	// Same encoding as a generator explicitly seeded with the big-endian bytes.
+	gorng.SetDefaultSeed(1337)
+	rng := gorng.NewGenerator([]byte{0, 0, 0, 0, 0, 0, 0x05, 0x39})
+	if got, want := gorng.RandomUint64(), rng.Uint64(); got != want {
+		t.Errorf("RandomUint64() = %x, want %x", got, want)
+	}
+}
+
+func Test_DefaultConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				gorng.RandomUint16()
+				if bits := gorng.RandomBits(12); len(bits) != 2 || bits[0] > 0x0F {
+					t.Errorf("RandomBits(12) = %x", bits)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// An invalid SEED_ENV panics on every use, not only the first, until a seed is
+// set explicitly.  Runs in a child process so the default generator is fresh.
+func Test_DefaultInvalidEnv(t *testing.T) {
+	if os.Getenv("GORNG_TEST_CHILD") == "1" {
+		for range 2 {
+			func() {
+				defer func() {
+					err, _ := recover().(error)
+					if err == nil || !strings.Contains(err.Error(), gorng.SEED_ENV) {
+						t.Errorf("expected a panic for an invalid seed, got %v", err)
+					}
+				}()
+				gorng.RandomUint64()
+			}()
+		}
+		gorng.SetDefaultSeed(1)
+		gorng.RandomUint64()
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^Test_DefaultInvalidEnv$")
+	cmd.Env = append(os.Environ(), "GORNG_TEST_CHILD=1", gorng.SEED_ENV+"=not-a-seed")
+	output, err := cmd.CombinedOutput()
+	if err != nil || !strings.Contains(string(output), "PASS") {
+		t.Errorf("child process failed: %v\n%s", err, output)
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dice/dice.go
+
+// Dice notation for tabletop-style rolls, e.g. "4d6dl1", "2d20kh1+5", "3d6!" or
+// "6d10>=8", parsed into an expression tree and evaluated against any
+// gorng.Source.  Each die is rolled with gorng's unbiased Int64Range(1, sides+1),
+// consuming one 64-bit word except for the rare rejections of Lemire's method,
+// so a roll can be replayed from the generator's seed.
+//
+// See Parse() for the grammar and Dice for the order in which modifiers apply.
+package dice
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// Upper bound on the number of dice in one term, including explosions.  Every
+// one of a term's Count dice is always rolled, and explosions may only add up
+// to MAX_DICE - Count more; once they have, dice that match are left as they
+// are without exploding.
+const MAX_DICE = 10000
+
+// A node in the expression tree: a Number, Dice, Negate or Binary.
+type Expr interface {
+	// The expression in canonical notation, which Parse() accepts.
+	String() string
+
+	eval(rng *gorng.Rand, result *Result) int64
+}
+
+// The outcome of evaluating an expression.
+type Result struct {
+	Total int64
+
+	// Every die rolled, in the order they were rolled.
+	Dice []Die
+}
+
+// A single die and how it contributed to the Result.
+type Die struct {
+	Sides int64
+	Value int64
+
+	// Earlier values of this die that were rerolled, in order.
+	Rerolls []int64
+
+	// Whether the die was added by an explosion of the one before it.
+	Exploded bool
+
+	// Whether the die was discarded by a keep or drop modifier.
+	Dropped bool
+}
+
+// Parses and evaluates the notation, see Parse().
+func Roll(src gorng.Source, notation string) (Result, error) {
+	expr, err := Parse(notation)
+	if err != nil {
+		return Result{}, err
+	}
+	return Evaluate(src, expr), nil
+}
+
+// Rolls all of the dice in the expression, from left to right.  Panics if a
+// Dice term is one Parse() would not produce: a Count outside 0..MAX_DICE, Sides
+// outside 1..MaxInt64-1, a negative KeepCount or a Reroll that matches every
+// face (which would never stop rerolling).
+func Evaluate(src gorng.Source, expr Expr) Result {
+	var result Result
+	result.Total = expr.eval(gorng.NewRand(src), &result)
+	return result
+}
+
+// A constant.
+type Number struct {
+	Value int64
+}
+
+func (number Number) String() string {
+	return strconv.FormatInt(number.Value, 10)
+}
+
+func (number Number) eval(*gorng.Rand, *Result) int64 {
+	return number.Value
+}
+
+// Arithmetic negation, as in "-d4".
+type Negate struct {
+	X Expr
+}
+
+func (negate Negate) String() string {
+	if _, ok := negate.X.(Binary); ok {
+		return "-(" + negate.X.String() + ")"
+	}
+	return "-" + negate.X.String()
+}
+
+func (negate Negate) eval(rng *gorng.Rand, result *Result) int64 {
+	return -negate.X.eval(rng, result)
+}
+
+// Addition, subtraction or multiplication, with Op one of '+', '-' or '*'.
+type Binary struct {
+	Op          byte
+	Left, Right Expr
+}
+
+func (binary Binary) String() string {
+	// Operands of lower or equal precedence are parenthesized on the right, as
+	// Parse() groups operators of equal precedence from the left.
+	left, right := binary.Left.String(), binary.Right.String()
+	if inner, ok := binary.Left.(Binary); ok && precedence(inner.Op) < precedence(binary.Op) {
+		left = "(" + left + ")"
+	}
+	if inner, ok := binary.Right.(Binary); ok && precedence(inner.Op) <= precedence(binary.Op) {
+		right = "(" + right + ")"
+	}
+	return left + string(binary.Op) + right
+}
+
+func precedence(op byte) int {
+	if op == '*' {
+		return 2
+	}
+	return 1
+}
+
+func (binary Binary) eval(rng *gorng.Rand, result *Result) int64 {
+	left := binary.Left.eval(rng, result)
+	right := binary.Right.eval(rng, result)
+	switch binary.Op {
+	case '+':
+		return left + right
+	case '-':
+		return left - right
+	case '*':
+		return left * right
+	}
+	panic("invalid operator " + string(binary.Op))
+}
+
+// A comparison against a die's value, with Op one of "=", "<", ">", "<=", ">=".
+type Compare struct {
+	Op    string
+	Value int64
+}
+
+func (compare Compare) Match(value int64) bool {
+	switch compare.Op {
+	case "=":
+		return value == compare.Value
+	case "<":
+		return value < compare.Value
+	case ">":
+		return value > compare.Value
+	case "<=":
+		return value <= compare.Value
+	case ">=":
+		return value >= compare.Value
+	}
+	return false
+}
+
+func (compare Compare) String() string {
+	return compare.Op + strconv.FormatInt(compare.Value, 10)
+}
+
+// Which dice a Keep modifier retains.
+type KeepMode int
+
+const (
+	KeepAll KeepMode = iota
+	KeepHighest
+	KeepLowest
+	DropHighest
+	DropLowest
+)
+
+var keepNotation = [...]string{"", "kh", "kl", "dh", "dl"}
+
+// A term of Count dice with Sides faces each, and its modifiers.  They apply in
+// this order:
+//
+//  1. The dice are rolled one at a time.  While a die matches Reroll, its value
+//     is moved to Rerolls and it is rolled again (once only, for RerollOnce).
+//  2. Each die that matches Explode is immediately followed by another die
+//     (itself subject to rerolls and explosions), until MAX_DICE - Count dice
+//     have been added this way; all Count dice are rolled regardless.
+//  3. Keep selects dice by value, across the original and exploded dice; among
+//     equal values, earlier dice count as lower.
+//  4. The term's value is the sum of the kept dice or, when Success is set, the
+//     number of kept dice that match it less the number that match Failure.
+type Dice struct {
+	Count, Sides int64
+
+	Reroll     *Compare
+	RerollOnce bool
+	Explode    *Compare
+	Keep       KeepMode
+	KeepCount  int64
+	Success    *Compare
+	Failure    *Compare
+}
+
+func (dice Dice) String() string {
+	var builder strings.Builder
+	if dice.Count != 1 {
+		builder.WriteString(strconv.FormatInt(dice.Count, 10))
+	}
+	builder.WriteString("d" + strconv.FormatInt(dice.Sides, 10))
+	if dice.Reroll != nil {
+		builder.WriteString("r")
+		if dice.RerollOnce {
+			builder.WriteString("o")
+		}
+		builder.WriteString(dice.Reroll.String())
+	}
+	if dice.Explode != nil {
+		builder.WriteString("!" + dice.Explode.String())
+	}
+	if dice.Keep != KeepAll {
+		builder.WriteString(keepNotation[dice.Keep] + strconv.FormatInt(dice.KeepCount, 10))
+	}
+	if dice.Success != nil {
+		builder.WriteString(dice.Success.String())
+	}
+	if dice.Failure != nil {
+		builder.WriteString("f" + dice.Failure.String())
+	}
+	return builder.String()
+}
+
+func (dice Dice) eval(rng *gorng.Rand, result *Result) int64 {
+	switch {
+	case dice.Count < 0 || dice.Count > MAX_DICE:
+		panic(fmt.Sprintf("dice: invalid count %d in %s", dice.Count, dice))
+	case dice.Sides < 1 || dice.Sides == math.MaxInt64:
+		panic(fmt.Sprintf("dice: invalid number of sides %d in %s", dice.Sides, dice))
+	case dice.KeepCount < 0:
+		panic(fmt.Sprintf("dice: invalid keep count %d in %s", dice.KeepCount, dice))
+	case dice.Reroll != nil && !dice.RerollOnce && matchesAll(dice.Reroll, dice.Sides):
+		panic(fmt.Sprintf("dice: reroll matches every face in %s", dice))
+	}
+
+	rolled := make([]Die, 0, dice.Count)
+	explosions := MAX_DICE - dice.Count
+	for i := int64(0); i < dice.Count; i++ {
+		die := dice.roll(rng, false)
+		rolled = append(rolled, die)
+		for dice.Explode != nil && dice.Explode.Match(die.Value) && explosions > 0 {
+			explosions--
+			die = dice.roll(rng, true)
+			rolled = append(rolled, die)
+		}
+	}
+
+	if dice.Keep != KeepAll {
+		order := make([]int, len(rolled))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return rolled[order[i]].Value < rolled[order[j]].Value
+		})
+		count := int(min(dice.KeepCount, int64(len(rolled))))
+		var dropped []int
+		switch dice.Keep {
+		case KeepHighest:
+			dropped = order[:len(order)-count]
+		case KeepLowest:
+			dropped = order[count:]
+		case DropHighest:
+			dropped = order[len(order)-count:]
+		case DropLowest:
+			dropped = order[:count]
+		}
+		for _, i := range dropped {
+			rolled[i].Dropped = true
+		}
+	}
+
+	total := int64(0)
+	for _, die := range rolled {
+		if die.Dropped {
+			continue
+		}
+		if dice.Success == nil {
+			total += die.Value
+			continue
+		}
+		if dice.Success.Match(die.Value) {
+			total++
+		}
+		if dice.Failure != nil && dice.Failure.Match(die.Value) {
+			total--
+		}
+	}
+	result.Dice = append(result.Dice, rolled...)
+	return total
+}
+
+func (dice Dice) roll(rng *gorng.Rand, exploded bool) Die {
+	die := Die{Sides: dice.Sides, Exploded: exploded}
+	die.Value = rng.Int64Range(1, dice.Sides+1)
+	for dice.Reroll != nil && dice.Reroll.Match(die.Value) {
+		die.Rerolls = append(die.Rerolls, die.Value)
+		die.Value = rng.Int64Range(1, dice.Sides+1)
+		if dice.RerollOnce {
+			break
+		}
+	}
+	return die
+}
@@ -0,0 +1,264 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dice/dice_test.go
+
+package dice_test
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/dice"
+)
+
+func roll(t *testing.T, seed string, notation string) dice.Result {
+	result, err := dice.Roll(gorng.NewSourceFromString(seed), notation)
+	if err != nil {
+		t.Fatalf("Roll(%q) error: %s", notation, err)
+	}
+	return result
+}
+
+// Each die is Int64Range(1, sides+1) on the source, in order.
+func Test_RollReplay(t *testing.T) {
+	result := roll(t, "replay", "3d6+2d20")
+	rng := gorng.NewSourceFromString("replay")
+	total := int64(0)
+	for i, sides := range []int64{6, 6, 6, 20, 20} {
+		want := rng.Int64Range(1, sides+1)
+		if die := result.Dice[i]; die.Sides != sides || die.Value != want {
+			t.Errorf("die %d = d%d rolled %d, want d%d rolled %d", i, die.Sides, die.Value, sides, want)
+		}
+		total += want
+	}
+	if result.Total != total {
+		t.Errorf("Total = %d, want %d", result.Total, total)
+	}
+
+	if again := roll(t, "replay", "3d6+2d20"); !reflect.DeepEqual(again, result) {
+		t.Errorf("the same seed rolled differently: %v, %v", result, again)
+	}
+}
+
+func Test_RollKeepDrop(t *testing.T) {
+	tests := []struct {
+		notation string
+		dropped  func(values []int64) []bool
+	}{
+		{"4d6dl1", func(v []int64) []bool { return lowest(v, 1) }},
+		{"5d10kh2", func(v []int64) []bool { return invert(highest(v, 2)) }},
+		{"5d10kl2", func(v []int64) []bool { return invert(lowest(v, 2)) }},
+		{"5d10dh3", func(v []int64) []bool { return highest(v, 3) }},
+		{"3d4kh5", func(v []int64) []bool { return make([]bool, len(v)) }},
+	}
+	for _, tt := range tests {
+		for seed := range 20 {
+			result := roll(t, string(rune('a'+seed)), tt.notation)
+			values := make([]int64, len(result.Dice))
+			for i, die := range result.Dice {
+				values[i] = die.Value
+			}
+			want := tt.dropped(values)
+			total := int64(0)
+			for i, die := range result.Dice {
+				if die.Dropped != want[i] {
+					t.Fatalf("%s rolled %v, die %d dropped = %v", tt.notation, values, i, die.Dropped)
+				}
+				if !die.Dropped {
+					total += die.Value
+				}
+			}
+			if result.Total != total {
+				t.Errorf("%s rolled %v, Total = %d, want %d", tt.notation, values, result.Total, total)
+			}
+		}
+	}
+}
+
+// Marks the n lowest values, preferring earlier dice among ties.
+func lowest(values []int64, n int) []bool {
+	marked := make([]bool, len(values))
+	for range n {
+		best := -1
+		for i, value := range values {
+			if !marked[i] && (best < 0 || value < values[best]) {
+				best = i
+			}
+		}
+		marked[best] = true
+	}
+	return marked
+}
+
+// Marks the n highest values, preferring later dice among ties.
+func highest(values []int64, n int) []bool {
+	marked := make([]bool, len(values))
+	for range n {
+		best := -1
+		for i, value := range values {
+			if !marked[i] && (best < 0 || value >= values[best]) {
+				best = i
+			}
+		}
+		marked[best] = true
+	}
+	return marked
+}
+
+func invert(marked []bool) []bool {
+	for i := range marked {
+		marked[i] = !marked[i]
+	}
+	return marked
+}
+
+func Test_RollExplode(t *testing.T) {
+	result := roll(t, "explode", "200d4!")
+	originals := 0
+	for i, die := range result.Dice {
+		if !die.Exploded {
+			originals++
+		}
+		exploding := die.Value == 4
+		if next := i + 1; next < len(result.Dice) && result.Dice[next].Exploded != exploding {
+			t.Fatalf("die %d rolled %d, followed by exploded = %v", i, die.Value, !exploding)
+		}
+	}
+	if originals != 200 || len(result.Dice) < 250 {
+		t.Errorf("%d original dice of %d, want 200 and about 267 in total", originals, len(result.Dice))
+	}
+
+	// Every requested die is rolled; explosions only fill the room left under
+	// MAX_DICE, and no die explodes once it is used up.
+	for _, tt := range []struct {
+		count, exploded int
+	}{{10000, 0}, {9990, 10}} {
+		notation := fmt.Sprintf("%dd2!", tt.count)
+		capped := roll(t, "explode", notation)
+		originals, exploded, total := 0, 0, int64(0)
+		for i, die := range capped.Dice {
+			total += die.Value
+			if !die.Exploded {
+				originals++
+				continue
+			}
+			exploded++
+			if previous := capped.Dice[i-1]; previous.Value != 2 {
+				t.Fatalf("%s: die %d exploded after a %d", notation, i, previous.Value)
+			}
+		}
+		if originals != tt.count || exploded != tt.exploded {
+			t.Errorf("%s: %d original and %d exploded dice, want %d and %d",
+				notation, originals, exploded, tt.count, tt.exploded)
+		}
+		if capped.Total != total {
+			t.Errorf("%s: Total = %d, want %d", notation, capped.Total, total)
+		}
+	}
+}
+
+func Test_RollReroll(t *testing.T) {
+	for _, die := range roll(t, "reroll", "500d6r<3").Dice {
+		if die.Value < 3 {
+			t.Fatalf("die kept value %d after rerolls %v", die.Value, die.Rerolls)
+		}
+		for _, value := range die.Rerolls {
+			if value >= 3 {
+				t.Fatalf("value %d was rerolled", value)
+			}
+		}
+	}
+
+	lowAfterOnce := 0
+	for _, die := range roll(t, "reroll", "500d6ro<3").Dice {
+		if len(die.Rerolls) > 1 {
+			t.Fatalf("die rerolled %d times, want at most once", len(die.Rerolls))
+		}
+		if die.Value < 3 {
+			lowAfterOnce++
+		}
+	}
+	if lowAfterOnce == 0 {
+		t.Error("rerolling once should sometimes keep a low value")
+	}
+}
+
+// Hand-built terms that Parse() would reject panic instead of misbehaving.
+func Test_EvaluateInvalid(t *testing.T) {
+	rng := gorng.NewSourceFromString("invalid")
+	for _, term := range []dice.Dice{
+		{Count: -1, Sides: 6},
+		{Count: math.MaxInt64, Sides: 6},
+		{Count: 1, Sides: 0},
+		{Count: 1, Sides: math.MaxInt64},
+		{Count: 2, Sides: 6, Keep: dice.KeepHighest, KeepCount: -1},
+		{Count: 1, Sides: 6, Reroll: &dice.Compare{"<=", 6}},
+	} {
+		func() {
+			defer func() {
+				if message := fmt.Sprint(recover()); !strings.HasPrefix(message, "dice: ") {
+					t.Errorf("Evaluate(%#v) panicked with %q", term, message)
+				}
+			}()
+			dice.Evaluate(rng, term)
+		}()
+	}
+}
+
+func Test_RollPool(t *testing.T) {
+	result := roll(t, "pool", "30d10>=8f1")
+	want := int64(0)
+	for _, die := range result.Dice {
+		if die.Value >= 8 {
+			want++
+		} else if die.Value == 1 {
+			want--
+		}
+	}
+	if result.Total != want {
+		t.Errorf("Total = %d, want %d successes less failures", result.Total, want)
+	}
+}
+
+func Test_RollDistribution(t *testing.T) {
+	const rolls = 20000
+	rng := gorng.NewSourceFromString("distribution")
+	expr := dice.MustParse("3d6")
+	counts := make([]float64, 19)
+	for range rolls {
+		counts[dice.Evaluate(rng, expr).Total]++
+	}
+	// Number of ways to roll each total with 3d6, out of 216.
+	ways := []float64{0, 0, 0, 1, 3, 6, 10, 15, 21, 25, 27, 27, 25, 21, 15, 10, 6, 3, 1}
+	chi2 := 0.0
+	for total := 3; total <= 18; total++ {
+		expected := rolls * ways[total] / 216
+		chi2 += (counts[total] - expected) * (counts[total] - expected) / expected
+	}
+	// Critical value for 15 degrees of freedom at p = 0.001.
+	if chi2 > 37.697 {
+		t.Errorf("3d6 totals %v deviate (chi-square %.2f)", counts[3:], chi2)
+	}
+}
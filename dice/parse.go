@@ -0,0 +1,298 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dice/parse.go
+
+package dice
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Parses dice notation into an expression tree.  Whitespace is ignored and
+// letters may be upper or lower case.  The grammar is
+//
+//	expr     = term { ("+" | "-") term }
+//	term     = unary { "*" unary }
+//	unary    = "-" unary | primary
+//	primary  = "(" expr ")" | number [ dice ] | dice
+//	dice     = "d" ( number | "%" ) { modifier }
+//	modifier = "r" [ "o" ] compare        reroll (once)
+//	         | "!" [ compare ]            explode, on the highest face by default
+//	         | ( "k" | "kh" | "kl" | "d" | "dh" | "dl" ) [ number ]
+//	         | compare                    count successes
+//	         | "f" compare                subtract failures
+//	compare  = [ "=" | "<" | ">" | "<=" | ">=" ] number
+//
+// where a number before "d" is the count of dice (default 1), "d%" has 100
+// sides, "k" means keep highest, "d" means drop lowest, and a keep or drop count
+// defaults to 1.  A compare without an operator tests equality, except that a
+// success count needs an operator to be told apart from the dice, and one
+// directly after "!" belongs to the explosion.  Each
+// modifier may appear at most once, a failure needs a success count, and rerolls
+// and explosions may not match every face of the die.
+func Parse(notation string) (Expr, error) {
+	p := &parser{notation: notation}
+	expr, err := p.expr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != 0 {
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+	return expr, nil
+}
+
+// Like Parse(), but panics if the notation is invalid.  For use with constant
+// notation, e.g. in package-level variables.
+func MustParse(notation string) Expr {
+	expr, err := Parse(notation)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
+type parser struct {
+	notation string
+	pos      int
+}
+
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("dice: %s at position %d of %q",
+		fmt.Sprintf(format, args...), p.pos, p.notation)
+}
+
+// Returns the next significant character in lower case, or 0 at the end.
+func (p *parser) peek() byte {
+	for p.pos < len(p.notation) && strings.IndexByte(" \t\r\n", p.notation[p.pos]) >= 0 {
+		p.pos++
+	}
+	if p.pos == len(p.notation) {
+		return 0
+	}
+	char := p.notation[p.pos]
+	if 'A' <= char && char <= 'Z' {
+		char += 'a' - 'A'
+	}
+	return char
+}
+
+// Consumes the next character if it is the expected one.
+func (p *parser) accept(char byte) bool {
+	if p.peek() == char {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func isDigit(char byte) bool {
+	return '0' <= char && char <= '9'
+}
+
+func (p *parser) number() (int64, error) {
+	if !isDigit(p.peek()) {
+		return 0, p.errorf("expected a number")
+	}
+	value := int64(0)
+	for isDigit(p.peek()) {
+		digit := int64(p.notation[p.pos] - '0')
+		if value > (math.MaxInt64-digit)/10 {
+			return 0, p.errorf("number too large")
+		}
+		value = 10*value + digit
+		p.pos++
+	}
+	return value, nil
+}
+
+func (p *parser) expr() (Expr, error) {
+	left, err := p.term()
+	for err == nil {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			break
+		}
+		p.pos++
+		var right Expr
+		if right, err = p.term(); err == nil {
+			left = Binary{op, left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) term() (Expr, error) {
+	left, err := p.unary()
+	for err == nil && p.accept('*') {
+		var right Expr
+		if right, err = p.unary(); err == nil {
+			left = Binary{'*', left, right}
+		}
+	}
+	return left, err
+}
+
+func (p *parser) unary() (Expr, error) {
+	if p.accept('-') {
+		x, err := p.unary()
+		return Negate{x}, err
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (Expr, error) {
+	if p.accept('(') {
+		expr, err := p.expr()
+		if err == nil && !p.accept(')') {
+			err = p.errorf("expected ')'")
+		}
+		return expr, err
+	}
+	count := int64(1)
+	if isDigit(p.peek()) {
+		var err error
+		if count, err = p.number(); err != nil {
+			return nil, err
+		}
+		if p.peek() != 'd' {
+			return Number{count}, nil
+		}
+	}
+	if !p.accept('d') {
+		if p.peek() == 0 {
+			return nil, p.errorf("unexpected end")
+		}
+		return nil, p.errorf("unexpected %q", p.peek())
+	}
+	return p.dice(count)
+}
+
+func (p *parser) dice(count int64) (Expr, error) {
+	dice := Dice{Count: count}
+	if count > MAX_DICE {
+		return nil, p.errorf("more than %d dice", MAX_DICE)
+	}
+	if p.accept('%') {
+		dice.Sides = 100
+	} else {
+		sides, err := p.number()
+		if err != nil {
+			return nil, err
+		}
+		if sides < 1 || sides == math.MaxInt64 {
+			return nil, p.errorf("invalid number of sides")
+		}
+		dice.Sides = sides
+	}
+
+	for {
+		var err error
+		switch char := p.peek(); {
+		case char == 'r' && dice.Reroll == nil:
+			p.pos++
+			dice.RerollOnce = p.accept('o')
+			dice.Reroll, err = p.compare(false)
+			if err == nil && matchesAll(dice.Reroll, dice.Sides) {
+				err = p.errorf("reroll matches every face")
+			}
+		case char == '!' && dice.Explode == nil:
+			p.pos++
+			dice.Explode = &Compare{"=", dice.Sides}
+			if op := p.peek(); isDigit(op) || strings.IndexByte("=<>", op) >= 0 {
+				dice.Explode, err = p.compare(false)
+			}
+			if err == nil && matchesAll(dice.Explode, dice.Sides) {
+				err = p.errorf("explosion matches every face")
+			}
+		case (char == 'k' || char == 'd') && dice.Keep == KeepAll:
+			p.pos++
+			switch {
+			case char == 'k' && p.accept('l'):
+				dice.Keep = KeepLowest
+			case char == 'k':
+				p.accept('h')
+				dice.Keep = KeepHighest
+			case p.accept('h'):
+				dice.Keep = DropHighest
+			default:
+				p.accept('l')
+				dice.Keep = DropLowest
+			}
+			dice.KeepCount = 1
+			if isDigit(p.peek()) {
+				dice.KeepCount, err = p.number()
+			}
+		case strings.IndexByte("=<>", char) >= 0 && dice.Success == nil:
+			dice.Success, err = p.compare(true)
+		case char == 'f' && dice.Success != nil && dice.Failure == nil:
+			p.pos++
+			dice.Failure, err = p.compare(false)
+		case strings.IndexByte("r!kdf=<>", char) >= 0:
+			if char == 'f' && dice.Success == nil {
+				return nil, p.errorf("failures without a success count")
+			}
+			return nil, p.errorf("repeated modifier %q", char)
+		default:
+			return dice, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (p *parser) compare(needOp bool) (*Compare, error) {
+	compare := &Compare{Op: "="}
+	switch {
+	case p.accept('='):
+	case p.accept('<'):
+		compare.Op = "<"
+		if p.accept('=') {
+			compare.Op = "<="
+		}
+	case p.accept('>'):
+		compare.Op = ">"
+		if p.accept('=') {
+			compare.Op = ">="
+		}
+	case needOp:
+		return nil, p.errorf("expected a comparison")
+	}
+	value, err := p.number()
+	compare.Value = value
+	return compare, err
+}
+
+// Whether every face from 1 to sides matches the comparison.
+func matchesAll(compare *Compare, sides int64) bool {
+	switch compare.Op {
+	case "=":
+		return sides == 1 && compare.Value == 1
+	case "<", "<=":
+		return compare.Match(sides)
+	case ">", ">=":
+		return compare.Match(1)
+	}
+	return false
+}
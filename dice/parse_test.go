@@ -0,0 +1,110 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dice/parse_test.go
+
+package dice_test
+
+import (
+	"testing"
+
+	"github.com/SymbolNotFound/gorng/dice"
+)
+
+func Test_Parse(t *testing.T) {
+	tests := []struct {
+		notation  string
+		canonical string
+	}{
+		{"d20", "d20"},
+		{"1d20", "d20"},
+		{"3D6", "3d6"},
+		{"d%", "d100"},
+		{"4d6 drop lowest", ""},
+		{"4d6d1", "4d6dl1"},
+		{"4d6dl", "4d6dl1"},
+		{"2d20kh1 + 5", "2d20kh1+5"},
+		{"2d20k", "2d20kh1"},
+		{"2d20kl1-1", "2d20kl1-1"},
+		{"5d8dh2", "5d8dh2"},
+		{"3d6!", "3d6!=6"},
+		{"3d6!>5", "3d6!>5"},
+		{"10d10!10", "10d10!=10"},
+		{"2d6r1", "2d6r=1"},
+		{"2d6ro<3", "2d6ro<3"},
+		{"6d10>=8", "6d10>=8"},
+		{"6d10>7f1", "6d10>7f=1"},
+		{"8d6!k3>=5", "8d6!=6kh3>=5"},
+		{"(1d4+1)*3", "(d4+1)*3"},
+		{"2*3+4", "2*3+4"},
+		{"2-(3-4)", "2-(3-4)"},
+		{"-d4", "-d4"},
+		{"--2", "--2"},
+		{"1d2+3d4-5*d6", "d2+3d4-5*d6"},
+	}
+	for _, tt := range tests {
+		expr, err := dice.Parse(tt.notation)
+		if tt.canonical == "" {
+			if err == nil {
+				t.Errorf("Parse(%q) expected an error, got %s", tt.notation, expr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Parse(%q) error: %s", tt.notation, err)
+			continue
+		}
+		if got := expr.String(); got != tt.canonical {
+			t.Errorf("Parse(%q).String() = %q, want %q", tt.notation, got, tt.canonical)
+		}
+		// The canonical notation parses back to the same tree.
+		if again := dice.MustParse(tt.canonical).String(); again != tt.canonical {
+			t.Errorf("Parse(%q).String() = %q, not canonical", tt.canonical, again)
+		}
+	}
+}
+
+func Test_ParseErrors(t *testing.T) {
+	tests := []string{
+		"",
+		"d",
+		"2d",
+		"d0",
+		"dx",
+		"2d6+",
+		"(2d6",
+		"2d6)",
+		"4d6dl1dl1",
+		"3d6!!",
+		"d1!",
+		"d6r<7",
+		"d6r>0",
+		"d6ro",
+		"2d6f1",
+		"6d10>7>8",
+		"20000d6",
+		"99999999999999999999",
+	}
+	for _, notation := range tests {
+		if expr, err := dice.Parse(notation); err == nil {
+			t.Errorf("Parse(%q) expected an error, got %s", notation, expr)
+		}
+	}
+}
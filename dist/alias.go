@@ -0,0 +1,248 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dist/alias.go
+
+package dist
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"math"
+	"math/bits"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// Weighted choice of an index in O(1) time with Walker's alias method, as
+// constructed by Vose, "A linear algorithm for generating random numbers with
+// a given distribution" (1991).
+//
+// The table has one column per weight.  Column i keeps i with probability
+// Numerators[i] / Denominator and otherwise gives Aliases[i], so a sample is
+//
+//	i := Uint64N(len(columns))
+//	r := Uint64N(Denominator)
+//	if r < Numerators[i] { return i } else { return Aliases[i] }
+//
+// with both bounded values drawn by gorng's unbiased Uint64N (Lemire's method).
+// Tables built from integer weights are exact: the probability of each index
+// is exactly its weight divided by the total.  Tables built from float weights
+// have a Denominator of 2^53, so each column's probability is rounded to the
+// nearest multiple of 2^-53, and the second draw always consumes one word.
+type AliasTable struct {
+	numerators  []uint64
+	aliases     []uint64
+	denominator uint64
+}
+
+var ErrInvalidWeights = errors.New("dist: weights must be non-negative and finite, with a positive sum")
+var ErrWeightsOverflow = errors.New("dist: the number of weights times their sum must be less than 2^64")
+
+// Builds a table with floating-point weights, which need not sum to 1.
+func NewAliasTable(weights []float64) (*AliasTable, error) {
+	total := 0.0
+	for _, weight := range weights {
+		if !(weight >= 0) || math.IsInf(weight, 1) {
+			return nil, ErrInvalidWeights
+		}
+		total += weight
+	}
+	if len(weights) == 0 || !(total > 0) || math.IsInf(total, 1) {
+		return nil, ErrInvalidWeights
+	}
+
+	n := float64(len(weights))
+	scaled := make([]float64, len(weights))
+	for i, weight := range weights {
+		scaled[i] = weight * n / total
+	}
+	table := &AliasTable{
+		numerators:  make([]uint64, len(weights)),
+		aliases:     make([]uint64, len(weights)),
+		denominator: 1 << 53,
+	}
+	vose(len(weights),
+		func(i int) bool { return scaled[i] < 1 },
+		func(small, large int) {
+			table.numerators[small] = uint64(math.Round(max(scaled[small], 0) * (1 << 53)))
+			scaled[large] = (scaled[large] + scaled[small]) - 1
+		},
+		table)
+	return table, nil
+}
+
+// Builds an exact table with integer weights.  The number of weights times
+// their sum must be less than 2^64.
+func NewAliasTableInt(weights []uint64) (*AliasTable, error) {
+	total := uint64(0)
+	for _, weight := range weights {
+		var carry uint64
+		total, carry = bits.Add64(total, weight, 0)
+		if carry != 0 {
+			return nil, ErrWeightsOverflow
+		}
+	}
+	if len(weights) == 0 || total == 0 {
+		return nil, ErrInvalidWeights
+	}
+	if hi, _ := bits.Mul64(total, uint64(len(weights))); hi != 0 {
+		return nil, ErrWeightsOverflow
+	}
+
+	n := uint64(len(weights))
+	scaled := make([]uint64, len(weights))
+	for i, weight := range weights {
+		scaled[i] = weight * n
+	}
+	table := &AliasTable{
+		numerators:  make([]uint64, len(weights)),
+		aliases:     make([]uint64, len(weights)),
+		denominator: total,
+	}
+	vose(len(weights),
+		func(i int) bool { return scaled[i] < total },
+		func(small, large int) {
+			table.numerators[small] = scaled[small]
+			scaled[large] -= total - scaled[small]
+		},
+		table)
+	return table, nil
+}
+
+// Vose's pairing of under-full and over-full columns, common to both kinds of
+// weights.  The small and large work lists are stacks (last in, first out),
+// initially filled in index order; each step pops one of each, lets the large
+// index be the small column's alias and moves the large index to whichever
+// list it now belongs on.  Columns left over at the end keep their own index
+// with probability 1.
+func vose(n int, isSmall func(i int) bool, pair func(small, large int), table *AliasTable) {
+	var small, large []int
+	for i := 0; i < n; i++ {
+		if isSmall(i) {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		pair(s, l)
+		table.aliases[s] = uint64(l)
+		if isSmall(l) {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	for _, i := range append(small, large...) {
+		table.numerators[i] = table.denominator
+		table.aliases[i] = uint64(i)
+	}
+}
+
+// The number of weights (and possible outcomes) of the table.
+func (table *AliasTable) Len() int {
+	return len(table.numerators)
+}
+
+// Returns an index in [0, Len()) with probability proportional to its weight.
+func (table *AliasTable) Sample(src gorng.Source) int64 {
+	r := gorng.NewRand(src)
+	i := r.Uint64N(uint64(len(table.numerators)))
+	if r.Uint64N(table.denominator) < table.numerators[i] {
+		return int64(i)
+	}
+	return int64(table.aliases[i])
+}
+
+// The binary format is "alias" | version (1 byte) | column count (8 bytes) |
+// denominator (8 bytes) | for each column, numerator and alias (8 bytes each),
+// with all integers big-endian.
+const aliasMagic = "alias"
+const aliasVersion = 1
+
+var ErrInvalidTable = errors.New("dist: invalid alias table")
+
+// Implements encoding.BinaryMarshaler.
+func (table *AliasTable) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, len(aliasMagic)+17+16*len(table.numerators))
+	data = append(data, aliasMagic...)
+	data = append(data, aliasVersion)
+	data = binary.BigEndian.AppendUint64(data, uint64(len(table.numerators)))
+	data = binary.BigEndian.AppendUint64(data, table.denominator)
+	for i := range table.numerators {
+		data = binary.BigEndian.AppendUint64(data, table.numerators[i])
+		data = binary.BigEndian.AppendUint64(data, table.aliases[i])
+	}
+	return data, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, validating the table's contents.
+func (table *AliasTable) UnmarshalBinary(data []byte) error {
+	header := len(aliasMagic) + 17
+	if len(data) < header ||
+		string(data[:len(aliasMagic)]) != aliasMagic ||
+		data[len(aliasMagic)] != aliasVersion {
+		return ErrInvalidTable
+	}
+	n := binary.BigEndian.Uint64(data[len(aliasMagic)+1:])
+	denominator := binary.BigEndian.Uint64(data[len(aliasMagic)+9:])
+	if n == 0 || denominator == 0 || uint64(len(data)-header) != 16*n || n > uint64(len(data)) {
+		return ErrInvalidTable
+	}
+
+	numerators := make([]uint64, n)
+	aliases := make([]uint64, n)
+	data = data[header:]
+	for i := range numerators {
+		numerators[i] = binary.BigEndian.Uint64(data)
+		aliases[i] = binary.BigEndian.Uint64(data[8:])
+		if numerators[i] > denominator || aliases[i] >= n {
+			return ErrInvalidTable
+		}
+		data = data[16:]
+	}
+	table.numerators, table.aliases, table.denominator = numerators, aliases, denominator
+	return nil
+}
+
+// Implements encoding.TextMarshaler, the binary format in standard base64.
+func (table *AliasTable) MarshalText() ([]byte, error) {
+	data, err := table.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.AppendEncode(nil, data), nil
+}
+
+// Implements encoding.TextUnmarshaler, the inverse of MarshalText().
+func (table *AliasTable) UnmarshalText(text []byte) error {
+	data, err := base64.StdEncoding.AppendDecode(nil, text)
+	if err != nil {
+		return ErrInvalidTable
+	}
+	return table.UnmarshalBinary(data)
+}
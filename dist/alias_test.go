@@ -0,0 +1,135 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dist/alias_test.go
+
+package dist_test
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/dist"
+)
+
+// Decodes the documented binary format and checks that the columns give each
+// index exactly its share: summed over columns, n * weight / total.
+func Test_AliasTableExact(t *testing.T) {
+	tests := [][]uint64{
+		{1},
+		{1, 1, 1},
+		{1, 2, 3, 4},
+		{0, 7, 0, 1, 100, 3},
+		{1 << 40, 1, 12345, 999999},
+	}
+	for _, weights := range tests {
+		table, err := dist.NewAliasTableInt(weights)
+		if err != nil {
+			t.Fatalf("NewAliasTableInt(%v) error: %s", weights, err)
+		}
+		data, _ := table.MarshalBinary()
+		denominator := binary.BigEndian.Uint64(data[14:])
+		n := uint64(len(weights))
+
+		shares := make([]uint64, n)
+		for i := uint64(0); i < n; i++ {
+			column := data[22+16*i:]
+			numerator := binary.BigEndian.Uint64(column)
+			alias := binary.BigEndian.Uint64(column[8:])
+			shares[i] += numerator
+			shares[alias] += denominator - numerator
+		}
+		for i, weight := range weights {
+			if shares[i] != weight*n {
+				t.Errorf("weights %v: index %d has share %d/%d, want %d",
+					weights, i, shares[i], denominator, weight*n)
+			}
+		}
+	}
+}
+
+func Test_AliasTableFloat(t *testing.T) {
+	const samples = 100000
+	weights := []float64{0.1, 0, 2.5, 0.4, 1}
+	table, err := dist.NewAliasTable(weights)
+	if err != nil {
+		t.Fatalf("NewAliasTable() error: %s", err)
+	}
+	src := gorng.NewSourceFromString("alias")
+	counts := make([]float64, len(weights))
+	for range samples {
+		counts[table.Sample(src)]++
+	}
+	if counts[1] != 0 {
+		t.Errorf("index with zero weight was chosen %v times", counts[1])
+	}
+	chi2 := 0.0
+	for i, weight := range weights {
+		if weight == 0 {
+			continue
+		}
+		expected := samples * weight / 4
+		chi2 += (counts[i] - expected) * (counts[i] - expected) / expected
+	}
+	// Critical value for 3 degrees of freedom at p = 0.001.
+	if chi2 > 16.266 {
+		t.Errorf("counts %v deviate (chi-square %.2f)", counts, chi2)
+	}
+}
+
+// A table restored from JSON samples identically to the original.
+func Test_AliasTableSerialization(t *testing.T) {
+	table, _ := dist.NewAliasTableInt([]uint64{5, 3, 9, 1, 1})
+	encoded, err := json.Marshal(table)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %s", err)
+	}
+	var restored dist.AliasTable
+	if err := json.Unmarshal(encoded, &restored); err != nil {
+		t.Fatalf("json.Unmarshal() error: %s", err)
+	}
+	one := gorng.NewSourceFromString("loot")
+	two := gorng.NewSourceFromString("loot")
+	for range 1000 {
+		if a, b := table.Sample(one), restored.Sample(two); a != b {
+			t.Fatalf("restored table diverged: %d != %d", a, b)
+		}
+	}
+
+	if err := restored.UnmarshalBinary([]byte("alias\x01")); err == nil {
+		t.Error("expected an error for a truncated table")
+	}
+}
+
+func Test_AliasTableInvalid(t *testing.T) {
+	for _, weights := range [][]float64{nil, {0, 0}, {1, -1}, {math.NaN()}, {math.Inf(1)}} {
+		if _, err := dist.NewAliasTable(weights); err == nil {
+			t.Errorf("NewAliasTable(%v) expected an error", weights)
+		}
+	}
+	for _, weights := range [][]uint64{nil, {0}, {math.MaxUint64, 1}, {1 << 63, 1 << 62}} {
+		if _, err := dist.NewAliasTableInt(weights); err == nil {
+			t.Errorf("NewAliasTableInt(%v) expected an error", weights)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dist/continuous.go
+
+package dist
+
+import (
+	"math"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// Below, u denotes uniform() and v denotes uniformOpen(), each one word.
+
+// Uniform on [Lo, Hi), Lo + (Hi - Lo) * u.  One word.
+type Uniform struct {
+	Lo, Hi float64
+}
+
+func (d Uniform) Sample(src gorng.Source) float64 {
+	if !(d.Lo <= d.Hi) {
+		panic("invalid argument to Uniform")
+	}
+	return d.Lo + float64((d.Hi-d.Lo)*uniform(src))
+}
+
+// Normal (Gaussian) with mean Mu and standard deviation Sigma, by the cosine
+// branch of the Box-Muller transform, Mu + Sigma * sqrt(-2 ln v) * cos(2π u),
+// with v drawn first and then u.  Two words.
+type Normal struct {
+	Mu, Sigma float64
+}
+
+func (d Normal) Sample(src gorng.Source) float64 {
+	if !(d.Sigma >= 0) {
+		panic("invalid argument to Normal")
+	}
+	return d.Mu + float64(d.Sigma*standardNormal(src))
+}
+
+func standardNormal(src gorng.Source) float64 {
+	radius := math.Sqrt(-2 * math.Log(uniformOpen(src)))
+	return radius * math.Cos(2*math.Pi*uniform(src))
+}
+
+// Log-normal, exp(Normal{Mu, Sigma}).  Two words.
+type LogNormal struct {
+	Mu, Sigma float64
+}
+
+func (d LogNormal) Sample(src gorng.Source) float64 {
+	if !(d.Sigma >= 0) {
+		panic("invalid argument to LogNormal")
+	}
+	return math.Exp(d.Mu + float64(d.Sigma*standardNormal(src)))
+}
+
+// Exponential with the given Rate (mean 1/Rate), by inversion, -ln(v) / Rate.
+// One word.
+type Exponential struct {
+	Rate float64
+}
+
+func (d Exponential) Sample(src gorng.Source) float64 {
+	if !(d.Rate > 0) {
+		panic("invalid argument to Exponential")
+	}
+	return -math.Log(uniformOpen(src)) / d.Rate
+}
+
+// Gamma with the given Shape (k) and Scale (θ), mean kθ, using the method of
+// Marsaglia and Tsang, "A Simple Method for Generating Gamma Variables" (2000).
+// For Shape >= 1, with d = Shape - 1/3 and c = 1 / sqrt(9d), each attempt is
+//
+//	x := Normal{0, 1} (two words)
+//	t := 1 + c*x; if t <= 0 the attempt fails (no more words drawn)
+//	w := t*t*t
+//	y := v (one word)
+//	accept if ln(y) < x*x/2 + d - d*w + d*ln(w), giving d * w * Scale
+//
+// and attempts are repeated until one is accepted (about 1.05 attempts on
+// average for any shape).  For Shape < 1, a sample g of Gamma{Shape + 1, Scale}
+// is drawn as above, then one more word for g * v^(1/Shape).
+type Gamma struct {
+	Shape, Scale float64
+}
+
+func (d Gamma) Sample(src gorng.Source) float64 {
+	if !(d.Shape > 0) || !(d.Scale > 0) {
+		panic("invalid argument to Gamma")
+	}
+	return standardGamma(src, d.Shape) * d.Scale
+}
+
+func standardGamma(src gorng.Source, shape float64) float64 {
+	if shape < 1 {
+		g := standardGamma(src, shape+1)
+		return g * math.Pow(uniformOpen(src), 1/shape)
+	}
+	d := shape - 1.0/3.0
+	c := 1 / math.Sqrt(9*d)
+	for {
+		x := standardNormal(src)
+		t := 1 + float64(c*x)
+		if t <= 0 {
+			continue
+		}
+		w := t * t * t
+		if math.Log(uniformOpen(src)) < float64(x*x/2)+d-float64(d*w)+float64(d*math.Log(w)) {
+			return d * w
+		}
+	}
+}
+
+// Beta on [0, 1] with shape parameters Alpha and Beta, as X / (X + Y) where X
+// is Gamma{Alpha, 1} and then Y is Gamma{Beta, 1}, drawn in that order.
+type Beta struct {
+	Alpha, Beta float64
+}
+
+func (d Beta) Sample(src gorng.Source) float64 {
+	if !(d.Alpha > 0) || !(d.Beta > 0) {
+		panic("invalid argument to Beta")
+	}
+	x := standardGamma(src, d.Alpha)
+	y := standardGamma(src, d.Beta)
+	return x / (x + y)
+}
+
+// Weibull with the given Shape (k) and Scale (λ), by inversion,
+// Scale * (-ln v)^(1/Shape).  One word.
+type Weibull struct {
+	Shape, Scale float64
+}
+
+func (d Weibull) Sample(src gorng.Source) float64 {
+	if !(d.Shape > 0) || !(d.Scale > 0) {
+		panic("invalid argument to Weibull")
+	}
+	return d.Scale * math.Pow(-math.Log(uniformOpen(src)), 1/d.Shape)
+}
+
+// Cauchy with the given Location (median) and Scale (half the interquartile
+// range), by inversion, Location + Scale * tan(π (v - 1/2)).  One word.
+type Cauchy struct {
+	Location, Scale float64
+}
+
+func (d Cauchy) Sample(src gorng.Source) float64 {
+	if !(d.Scale > 0) {
+		panic("invalid argument to Cauchy")
+	}
+	return d.Location + float64(d.Scale*math.Tan(math.Pi*(uniformOpen(src)-0.5)))
+}
+
+// Triangular on [Lo, Hi] with its peak at Mode, by inversion of the CDF with
+// one word u: with f = (Mode - Lo) / (Hi - Lo),
+//
+//	u < f:  Lo + sqrt(u (Hi - Lo) (Mode - Lo))
+//	u >= f: Hi - sqrt((1 - u) (Hi - Lo) (Hi - Mode))
+type Triangular struct {
+	Lo, Mode, Hi float64
+}
+
+func (d Triangular) Sample(src gorng.Source) float64 {
+	if !(d.Lo <= d.Mode && d.Mode <= d.Hi && d.Lo < d.Hi) {
+		panic("invalid argument to Triangular")
+	}
+	u := uniform(src)
+	width := d.Hi - d.Lo
+	if u < (d.Mode-d.Lo)/width {
+		return d.Lo + math.Sqrt(u*width*(d.Mode-d.Lo))
+	}
+	return d.Hi - math.Sqrt((1-u)*width*(d.Hi-d.Mode))
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dist/continuous_test.go
+
+package dist_test
+
+import (
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/dist"
+)
+
+// Checks the sample mean and variance against their known values, within six
+// standard errors (the fourth moment is bounded loosely by the tolerance).
+func Test_ContinuousMoments(t *testing.T) {
+	tests := []struct {
+		name     string
+		dist     dist.Continuous
+		mean     float64
+		variance float64
+	}{
+		{"uniform", dist.Uniform{-1, 3}, 1, 16.0 / 12},
+		{"normal", dist.Normal{5, 2}, 5, 4},
+		{"lognormal", dist.LogNormal{0, 0.5},
+			math.Exp(0.125), (math.Exp(0.25) - 1) * math.Exp(0.25)},
+		{"exponential", dist.Exponential{4}, 0.25, 0.0625},
+		{"gamma", dist.Gamma{3, 2}, 6, 12},
+		{"gamma small shape", dist.Gamma{0.3, 1}, 0.3, 0.3},
+		{"beta", dist.Beta{2, 5}, 2.0 / 7, 10.0 / (49 * 8)},
+		{"weibull", dist.Weibull{2, 1},
+			math.Gamma(1.5), math.Gamma(2) - math.Gamma(1.5)*math.Gamma(1.5)},
+		{"triangular", dist.Triangular{0, 1, 4}, 5.0 / 3, (1 + 16 - 4) / 18.0},
+	}
+	const samples = 40000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := gorng.NewSourceFromString(tt.name)
+			sum, sumSquares := 0.0, 0.0
+			for range samples {
+				x := tt.dist.Sample(src)
+				sum += x
+				sumSquares += x * x
+			}
+			mean := sum / samples
+			variance := sumSquares/samples - mean*mean
+
+			stderr := math.Sqrt(tt.variance / samples)
+			if math.Abs(mean-tt.mean) > 6*stderr {
+				t.Errorf("mean %.5f, expected %.5f (± %.5f)", mean, tt.mean, 6*stderr)
+			}
+			if math.Abs(variance-tt.variance) > 0.05*tt.variance {
+				t.Errorf("variance %.5f, expected %.5f", variance, tt.variance)
+			}
+		})
+	}
+}
+
+// The Cauchy distribution has no mean, so check its quartiles instead.
+func Test_CauchyQuartiles(t *testing.T) {
+	const samples = 40000
+	src := gorng.NewSourceFromString("cauchy")
+	d := dist.Cauchy{Location: 10, Scale: 3}
+	values := make([]float64, samples)
+	for i := range values {
+		values[i] = d.Sample(src)
+	}
+	sort.Float64s(values)
+	for i, want := range []float64{7, 10, 13} {
+		got := values[(i+1)*samples/4]
+		if math.Abs(got-want) > 0.15 {
+			t.Errorf("quartile %d = %.3f, want %.3f", i+1, got, want)
+		}
+	}
+}
+
+// The documented number of words is drawn for each sample.
+func Test_ContinuousConsumption(t *testing.T) {
+	tests := []struct {
+		name  string
+		dist  dist.Continuous
+		words int
+	}{
+		{"uniform", dist.Uniform{0, 1}, 1},
+		{"normal", dist.Normal{0, 1}, 2},
+		{"lognormal", dist.LogNormal{0, 1}, 2},
+		{"exponential", dist.Exponential{1}, 1},
+		{"weibull", dist.Weibull{1.5, 1}, 1},
+		{"cauchy", dist.Cauchy{0, 1}, 1},
+		{"triangular", dist.Triangular{0, 0.5, 1}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := &countingSource{src: gorng.NewSourceFromString(tt.name)}
+			for range 100 {
+				tt.dist.Sample(src)
+			}
+			if src.count != 100*tt.words {
+				t.Errorf("consumed %d words for 100 samples, want %d", src.count, 100*tt.words)
+			}
+		})
+	}
+
+	// Gamma draws three words per attempt, and almost always accepts the first.
+	src := &countingSource{src: gorng.NewSourceFromString("gamma")}
+	for range 1000 {
+		dist.Gamma{Shape: 5, Scale: 1}.Sample(src)
+	}
+	if src.count%3 != 0 || src.count > 3*1100 {
+		t.Errorf("consumed %d words for 1000 gamma samples", src.count)
+	}
+}
+
+// Same seed, same samples.
+func Test_ContinuousRepeatable(t *testing.T) {
+	one := gorng.NewSourceFromString("repeat")
+	two := gorng.NewSourceFromString("repeat")
+	d := dist.Beta{Alpha: 0.5, Beta: 3}
+	for range 1000 {
+		if a, b := d.Sample(one), d.Sample(two); a != b {
+			t.Fatalf("samples diverged: %v != %v", a, b)
+		}
+	}
+}
+
+// Counts the words drawn from the wrapped source.
+type countingSource struct {
+	src   gorng.Source
+	count int
+}
+
+func (source *countingSource) Uint64() uint64 {
+	source.count++
+	return source.src.Uint64()
+}
@@ -0,0 +1,387 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dist/discrete.go
+
+package dist
+
+import (
+	"math"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// As in continuous.go, u denotes uniform() and v denotes uniformOpen(), each
+// one word.  Samplers with degenerate parameters (e.g. a probability of 0 or 1)
+// return their only possible value without drawing any words.  Samples that are
+// too large for an int64 (e.g. from a Lambda of 1e30) saturate at MaxInt64.
+
+// Poisson with mean Lambda.
+//
+// For Lambda < 10, by multiplication of uniforms (Knuth): with L = exp(-Lambda),
+// words v are drawn and multiplied together until the product is <= L, and the
+// number of words drawn minus one is returned (so k+1 words for the result k).
+//
+// For Lambda >= 10, by the transformed rejection method PTRS of Hörmann, "The
+// transformed rejection method for generating Poisson random variables" (1993).
+// Each attempt draws two words, U = u - 0.5 and then V = u; see poissonPTRS().
+type Poisson struct {
+	Lambda float64
+}
+
+func (d Poisson) Sample(src gorng.Source) int64 {
+	if !(d.Lambda >= 0) || math.IsInf(d.Lambda, 1) {
+		panic("invalid argument to Poisson")
+	}
+	if d.Lambda == 0 {
+		return 0
+	}
+	if d.Lambda < 10 {
+		return poissonMultiplication(src, d.Lambda)
+	}
+	return poissonPTRS(src, d.Lambda)
+}
+
+func poissonMultiplication(src gorng.Source, lambda float64) int64 {
+	limit := math.Exp(-lambda)
+	product := uniformOpen(src)
+	k := int64(0)
+	for product > limit {
+		product *= uniformOpen(src)
+		k++
+	}
+	return k
+}
+
+// Each attempt, with us = 0.5 - |U|, computes k = floor((2a/us + b) U + λ + 0.43)
+// and accepts immediately when us >= 0.07 and V <= vr; otherwise it is rejected
+// when k < 0 or (us < 0.013 and V > us), and finally accepted if
+//
+//	ln(V) + ln(invalpha) - ln(a / us² + b) <= -λ + k ln(λ) - ln(k!)
+func poissonPTRS(src gorng.Source, lambda float64) int64 {
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + float64(2.53*slam)
+	a := -0.059 + float64(0.02483*b)
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+
+	for {
+		U := uniform(src) - 0.5
+		V := uniform(src)
+		us := 0.5 - math.Abs(U)
+		k := math.Floor(float64((2*a/us+b)*U) + lambda + 0.43)
+		if us >= 0.07 && V <= vr {
+			return saturateInt64(k)
+		}
+		if k < 0 || (us < 0.013 && V > us) {
+			continue
+		}
+		lgam, _ := math.Lgamma(k + 1)
+		if math.Log(V)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -lambda+float64(k*loglam)-lgam {
+			return saturateInt64(k)
+		}
+	}
+}
+
+// Binomial, the number of successes in N trials each with probability P.
+//
+// The sampler works with r = min(P, 1-P) and returns N - x when P > 0.5.  When
+// N*r <= 30 it uses inversion (see binomialInversion()) and otherwise BTPE, the
+// triangle-parallelogram-exponential method of Kachitvichyanukul and Schmeiser,
+// "Binomial random variate generation" (1988), drawing two words per attempt.
+type Binomial struct {
+	N int64
+	P float64
+}
+
+func (d Binomial) Sample(src gorng.Source) int64 {
+	if d.N < 0 || !(d.P >= 0 && d.P <= 1) {
+		panic("invalid argument to Binomial")
+	}
+	if d.N == 0 || d.P == 0 {
+		return 0
+	}
+	if d.P == 1 {
+		return d.N
+	}
+
+	r := min(d.P, 1-d.P)
+	var x int64
+	if float64(d.N)*r <= 30 {
+		x = binomialInversion(src, d.N, r)
+	} else {
+		x = binomialBTPE(src, d.N, r)
+	}
+	if d.P > 0.5 {
+		x = d.N - x
+	}
+	return x
+}
+
+// Sequential search from 0 with one word U = u: while U > P(X = x), subtract
+// P(X = x) from U and move to x+1.  If x passes the bound n*p + 10 sqrt(n*p*q+1)
+// (reachable only through rounding) the search restarts with a new word.
+func binomialInversion(src gorng.Source, n int64, p float64) int64 {
+	q := 1 - p
+	qn := math.Exp(float64(n) * math.Log(q))
+	np := float64(float64(n) * p)
+	bound := min(float64(n), np+float64(10*math.Sqrt(float64(np*q)+1)))
+
+	x := int64(0)
+	px := qn
+	U := uniform(src)
+	for U > px {
+		x++
+		if float64(x) > bound {
+			x = 0
+			px = qn
+			U = uniform(src)
+		} else {
+			U -= px
+			px = (float64(n-x+1) * p * px) / (float64(x) * q)
+		}
+	}
+	return x
+}
+
+// BTPE for p <= 0.5, following the step numbering of the paper.  Each attempt
+// draws U = u * p4 and then V = u, selecting the triangular, parallelogram or
+// one of the exponential regions by U and accepting with V.
+func binomialBTPE(src gorng.Source, n int64, p float64) int64 {
+	nf := float64(n)
+	q := 1 - p
+	fm := float64(nf*p) + p
+	m := math.Floor(fm)
+	p1 := math.Floor(float64(2.195*math.Sqrt(nf*p*q))-float64(4.6*q)) + 0.5
+	xm := m + 0.5
+	xl := xm - p1
+	xr := xm + p1
+	c := 0.134 + 20.5/(15.3+m)
+	a := (fm - xl) / (fm - float64(xl*p))
+	laml := a * (1 + float64(a/2))
+	a = (xr - fm) / (xr * q)
+	lamr := a * (1 + float64(a/2))
+	p2 := float64(p1 * (1 + float64(2*c)))
+	p3 := p2 + c/laml
+	p4 := p3 + c/lamr
+	nrq := float64(nf * p * q)
+
+	for {
+		// Step 10: the triangular region.
+		u := float64(uniform(src) * p4)
+		v := uniform(src)
+		var y float64
+		if u <= p1 {
+			return int64(math.Floor(xm - float64(p1*v) + u))
+		}
+
+		if u <= p2 { // Step 20: the parallelograms.
+			x := xl + (u-p1)/c
+			v = float64(v*c) + 1 - math.Abs(m-x+0.5)/p1
+			if v > 1 {
+				continue
+			}
+			y = math.Floor(x)
+		} else if u <= p3 { // Step 30: the left exponential tail.
+			y = math.Floor(xl + math.Log(v)/laml)
+			if y < 0 || v == 0 {
+				continue
+			}
+			v = v * (u - p2) * laml
+		} else { // Step 40: the right exponential tail.
+			y = math.Floor(xr - math.Log(v)/lamr)
+			if y > nf || v == 0 {
+				continue
+			}
+			v = v * (u - p3) * lamr
+		}
+
+		// Step 50: acceptance, explicitly for small distances from the mode.
+		k := math.Abs(y - m)
+		if k <= 20 || k >= float64(nrq/2)-1 {
+			s := p / q
+			a := s * (nf + 1)
+			f := 1.0
+			if m < y {
+				for i := m + 1; i <= y; i++ {
+					f *= a/i - s
+				}
+			} else if m > y {
+				for i := y + 1; i <= m; i++ {
+					f /= a/i - s
+				}
+			}
+			if v > f {
+				continue
+			}
+			return int64(y)
+		}
+
+		// Step 52: squeezing, then the final test with Stirling's formula.
+		rho := float64((k / nrq) * ((float64(k*(k/3+0.625))+0.1666666666666)/nrq + 0.5))
+		t := -k * k / (2 * nrq)
+		A := math.Log(v)
+		if A < t-rho {
+			return int64(y)
+		}
+		if A > t+rho {
+			continue
+		}
+		x1 := y + 1
+		f1 := m + 1
+		z := nf + 1 - m
+		w := nf - y + 1
+		bound := float64(xm*math.Log(f1/x1)) +
+			float64((nf-m+0.5)*math.Log(z/w)) +
+			float64((y-m)*math.Log(w*p/(x1*q))) +
+			stirling(f1) + stirling(z) + stirling(x1) + stirling(w)
+		if A > bound {
+			continue
+		}
+		return int64(y)
+	}
+}
+
+// Converts a non-negative integral value to int64, saturating at MaxInt64 when
+// it is 2^63 or larger (including +Inf).
+func saturateInt64(x float64) int64 {
+	if x >= 0x1p63 {
+		return math.MaxInt64
+	}
+	return int64(x)
+}
+
+// The correction term of Stirling's approximation used by BTPE's final test.
+func stirling(a float64) float64 {
+	a2 := a * a
+	return (13860 - (462-(132-(99-140/a2)/a2)/a2)/a2) / a / 166320
+}
+
+// Geometric, the number of failures before the first success of trials each
+// with probability P, by inversion, floor(ln(v) / log1p(-P)).  One word.  The
+// denominator is log1p(-P), i.e. ln(1 - P) computed without first rounding 1 - P,
+// which matters for small P; ports must use their log1p to get the same values.
+type Geometric struct {
+	P float64
+}
+
+func (d Geometric) Sample(src gorng.Source) int64 {
+	if !(d.P > 0 && d.P <= 1) {
+		panic("invalid argument to Geometric")
+	}
+	if d.P == 1 {
+		return 0
+	}
+	return saturateInt64(math.Floor(math.Log(uniformOpen(src)) / math.Log1p(-d.P)))
+}
+
+// Negative binomial, the number of failures before the R'th success of trials
+// each with probability P (R need not be an integer), as a gamma-Poisson
+// mixture: λ is drawn from Gamma{R, (1 - P) / P} and then Poisson{λ}.
+type NegativeBinomial struct {
+	R float64
+	P float64
+}
+
+func (d NegativeBinomial) Sample(src gorng.Source) int64 {
+	if !(d.R > 0) || !(d.P > 0 && d.P <= 1) {
+		panic("invalid argument to NegativeBinomial")
+	}
+	if d.P == 1 {
+		return 0
+	}
+	lambda := Gamma{d.R, (1 - d.P) / d.P}.Sample(src)
+	return Poisson{lambda}.Sample(src)
+}
+
+// Hypergeometric, the number of successes when Draws items are taken without
+// replacement from Total items of which Successes are successes.
+//
+// By inversion from the mode with one word U = u: the probabilities are summed
+// starting at the mode and then alternating between the next value above and
+// the next value below (while each is within the support), returning the value
+// at which the running sum first exceeds U.  The probability of the mode is
+// computed with log-gamma functions and the rest by the recurrence between
+// neighboring values, which does not underflow for large parameters and takes
+// O(standard deviation) steps.
+type Hypergeometric struct {
+	Total, Successes, Draws int64
+}
+
+func (d Hypergeometric) Sample(src gorng.Source) int64 {
+	N, K, n := d.Total, d.Successes, d.Draws
+	if N < 0 || K < 0 || n < 0 || K > N || n > N {
+		panic("invalid argument to Hypergeometric")
+	}
+	lo := max(0, n-(N-K))
+	hi := min(n, K)
+	if lo == hi {
+		return lo
+	}
+
+	// P(X = k+1) / P(X = k) and P(X = k-1) / P(X = k).
+	up := func(k int64) float64 {
+		return float64(K-k) * float64(n-k) / (float64(k+1) * float64(N-K-n+k+1))
+	}
+	down := func(k int64) float64 {
+		return float64(k) * float64(N-K-n+k) / (float64(K-k+1) * float64(n-k+1))
+	}
+
+	mode := int64(float64(n+1) * float64(K+1) / float64(N+2))
+	mode = min(max(mode, lo), hi)
+	pmode := math.Exp(logChoose(K, mode) + logChoose(N-K, n-mode) - logChoose(N, n))
+
+	U := uniform(src)
+	if U < pmode {
+		return mode
+	}
+	U -= pmode
+	above, below := mode+1, mode-1
+	pabove, pbelow := pmode, pmode
+	for above <= hi || below >= lo {
+		if above <= hi {
+			pabove = float64(pabove * up(above-1))
+			if U < pabove {
+				return above
+			}
+			U -= pabove
+			above++
+		}
+		if below >= lo {
+			pbelow = float64(pbelow * down(below+1))
+			if U < pbelow {
+				return below
+			}
+			U -= pbelow
+			below--
+		}
+	}
+	// Only reachable through rounding, the probabilities summed to less than U.
+	return mode
+}
+
+// The natural logarithm of the binomial coefficient (n choose k).
+func logChoose(n, k int64) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
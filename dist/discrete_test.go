@@ -0,0 +1,159 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dist/discrete_test.go
+
+package dist_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/dist"
+)
+
+// Checks the sample mean and variance against their known values, including
+// parameters large enough to exercise the rejection samplers.
+func Test_DiscreteMoments(t *testing.T) {
+	tests := []struct {
+		name     string
+		dist     dist.Discrete
+		mean     float64
+		variance float64
+	}{
+		{"poisson small", dist.Poisson{3.5}, 3.5, 3.5},
+		{"poisson ptrs", dist.Poisson{45}, 45, 45},
+		{"poisson huge", dist.Poisson{1e9}, 1e9, 1e9},
+		{"binomial inversion", dist.Binomial{40, 0.2}, 8, 6.4},
+		{"binomial flipped", dist.Binomial{40, 0.9}, 36, 3.6},
+		{"binomial btpe", dist.Binomial{1000, 0.3}, 300, 210},
+		{"binomial huge", dist.Binomial{1 << 40, 0.75}, 0.75 * (1 << 40), 0.1875 * (1 << 40)},
+		{"geometric", dist.Geometric{0.25}, 3, 12},
+		{"negative binomial", dist.NegativeBinomial{4.5, 0.6}, 3, 5},
+		{"hypergeometric", dist.Hypergeometric{52, 13, 5}, 1.25, 1.25 * 39.0 / 52 * 47.0 / 51},
+		{"hypergeometric huge", dist.Hypergeometric{1e12, 3e11, 1e4},
+			3e3, 1e4 * 0.3 * 0.7 * (1e12 - 1e4) / (1e12 - 1)},
+	}
+	const samples = 40000
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := gorng.NewSourceFromString(tt.name)
+			sum, sumSquares := 0.0, 0.0
+			for range samples {
+				x := float64(tt.dist.Sample(src)) - tt.mean
+				sum += x
+				sumSquares += x * x
+			}
+			mean := sum / samples
+			variance := sumSquares/samples - mean*mean
+			mean += tt.mean
+
+			stderr := math.Sqrt(tt.variance / samples)
+			if math.Abs(mean-tt.mean) > 6*stderr {
+				t.Errorf("mean %.5f, expected %.5f (± %.5f)", mean, tt.mean, 6*stderr)
+			}
+			if math.Abs(variance-tt.variance) > 0.06*tt.variance {
+				t.Errorf("variance %.5f, expected %.5f", variance, tt.variance)
+			}
+		})
+	}
+}
+
+// A chi-square test of the BTPE sampler against the exact probabilities.
+func Test_BinomialDistribution(t *testing.T) {
+	const samples = 100000
+	d := dist.Binomial{N: 200, P: 0.4}
+	src := gorng.NewSourceFromString("binomial pmf")
+	counts := make(map[int64]float64)
+	for range samples {
+		counts[d.Sample(src)]++
+	}
+
+	chi2, bins := 0.0, 0
+	for k := int64(60); k <= 100; k++ {
+		logp := logChoose(200, k) + float64(k)*math.Log(0.4) + float64(200-k)*math.Log(0.6)
+		expected := samples * math.Exp(logp)
+		chi2 += (counts[k] - expected) * (counts[k] - expected) / expected
+		bins++
+	}
+	// Critical value for 40 degrees of freedom at p = 0.001.
+	if chi2 > 73.402 {
+		t.Errorf("binomial counts deviate (chi-square %.2f over %d bins)", chi2, bins)
+	}
+}
+
+func Test_DiscreteDegenerate(t *testing.T) {
+	src := &countingSource{src: gorng.NewSourceFromString("degenerate")}
+	tests := []struct {
+		name string
+		dist dist.Discrete
+		want int64
+	}{
+		{"poisson", dist.Poisson{0}, 0},
+		{"binomial none", dist.Binomial{10, 0}, 0},
+		{"binomial all", dist.Binomial{10, 1}, 10},
+		{"geometric", dist.Geometric{1}, 0},
+		{"hypergeometric all", dist.Hypergeometric{10, 4, 10}, 4},
+	}
+	for _, tt := range tests {
+		if got := tt.dist.Sample(src); got != tt.want {
+			t.Errorf("%s = %d, want %d", tt.name, got, tt.want)
+		}
+	}
+	if src.count != 0 {
+		t.Errorf("degenerate distributions consumed %d words", src.count)
+	}
+
+	for range 1000 {
+		if (dist.Hypergeometric{20, 15, 12}).Sample(src) < 7 {
+			t.Fatal("hypergeometric sample below its support")
+		}
+	}
+}
+
+// Samples beyond the range of int64 saturate instead of wrapping around.
+func Test_DiscreteSaturate(t *testing.T) {
+	src := gorng.NewSourceFromString("saturate")
+	if got := (dist.Poisson{1e30}).Sample(src); got != math.MaxInt64 {
+		t.Errorf("Poisson{1e30} = %d, want MaxInt64", got)
+	}
+	saturated := 0
+	for range 1000 {
+		got := (dist.Geometric{1e-19}).Sample(src)
+		if got < 1<<50 {
+			t.Fatalf("Geometric{1e-19} = %d", got)
+		}
+		if got == math.MaxInt64 {
+			saturated++
+		}
+	}
+	// Saturates when v <= exp(-2^63 * 1e-19), with probability about 0.4.
+	if saturated < 300 || saturated > 500 {
+		t.Errorf("Geometric{1e-19} saturated %d of 1000 times", saturated)
+	}
+}
+
+func logChoose(n, k int64) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dist/dist.go
+
+// Probability distributions that draw from any gorng.Source.
+//
+// Every sampler documents exactly how many 64-bit words it consumes from the
+// source and how they are turned into a sample, so that a port to another
+// language draws the same words for the same purposes.
+//
+// The arithmetic in this package is the same on every platform: each product
+// that feeds an addition or subtraction is rounded with an explicit float64()
+// conversion, which the Go spec guarantees prevents the compiler from fusing it
+// into an FMA instruction (as it otherwise may on arm64, loong64, ppc64le,
+// riscv64 and s390x).  Ports must likewise round each product before adding it.
+// Sqrt and Floor are exact everywhere.  What can still differ, in the last bit,
+// are the math package functions used here:
+//
+//   - Exp has assembly versions on amd64 (using FMA instructions on CPUs that
+//     have them, so even two amd64 machines can differ), arm64, loong64,
+//     riscv64 and s390x.
+//   - Log has assembly versions on amd64 and s390x; Log1p, Cos and Tan on s390x.
+//   - Log, Log1p, Cos, Tan, Pow and Lgamma are otherwise written in Go, but the
+//     math package is compiled with fusion allowed, so their results on the FMA
+//     platforms above can differ from those on amd64.
+//
+// Uniform, Triangular and the alias tables use none of these functions, so their
+// samples are identical everywhere.  For the rest, an integer sample changes
+// only when a last-bit difference moves a value across a rounding or acceptance
+// boundary, which is rare.
+//
+// Invalid parameters (e.g. a negative scale) cause a panic when sampling, in
+// the same way that gorng.Rand does for invalid bounds.
+package dist
+
+import "github.com/SymbolNotFound/gorng"
+
+// A distribution over real numbers.
+type Continuous interface {
+	Sample(src gorng.Source) float64
+}
+
+// A distribution over integers.
+type Discrete interface {
+	Sample(src gorng.Source) int64
+}
+
+// A uniform value in [0, 1) from the 53 most significant bits of one word, the
+// same as gorng.Rand.Float64().  Scaling by 2^-53 is exact, but the product is
+// still rounded explicitly so that no caller's addition can be fused with it.
+func uniform(src gorng.Source) float64 {
+	return float64(float64(src.Uint64()>>11) * 0x1p-53)
+}
+
+// A uniform value in (0, 1) from the 53 most significant bits of one word, the
+// same lattice as uniform() but shifted up by half a step so it is never 0.
+func uniformOpen(src gorng.Source) float64 {
+	return float64((float64(src.Uint64()>>11) + 0.5) * 0x1p-53)
+}
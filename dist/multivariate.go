@@ -0,0 +1,223 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dist/multivariate.go
+
+package dist
+
+import (
+	"math"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// Multinomial, how N trials are split between categories with the relative
+// weights in Weights (which need not sum to 1).  By the conditional binomial
+// method: for each category but the last, in order, the count is drawn from
+// Binomial{remaining trials, weight / remaining weight}, and the last category
+// receives whatever trials remain.
+type Multinomial struct {
+	N       int64
+	Weights []float64
+}
+
+func (d Multinomial) Sample(src gorng.Source) []int64 {
+	total := 0.0
+	for _, weight := range d.Weights {
+		if !(weight >= 0) {
+			panic("invalid argument to Multinomial")
+		}
+		total += weight
+	}
+	if d.N < 0 || len(d.Weights) == 0 || !(total > 0) {
+		panic("invalid argument to Multinomial")
+	}
+
+	counts := make([]int64, len(d.Weights))
+	remaining := d.N
+	for i, weight := range d.Weights[:len(d.Weights)-1] {
+		if remaining == 0 {
+			break
+		}
+		p := 0.0
+		if total > 0 {
+			p = min(weight/total, 1)
+		}
+		counts[i] = Binomial{remaining, p}.Sample(src)
+		remaining -= counts[i]
+		total -= weight
+	}
+	counts[len(counts)-1] += remaining
+	return counts
+}
+
+// Dirichlet, random weights on the simplex (non-negative, summing to 1) with
+// concentration parameters Alpha.  One Gamma{Alpha[i], 1} is drawn for each
+// parameter in order and the results are divided by their sum.
+type Dirichlet struct {
+	Alpha []float64
+}
+
+func (d Dirichlet) Sample(src gorng.Source) []float64 {
+	if len(d.Alpha) == 0 {
+		panic("invalid argument to Dirichlet")
+	}
+	weights := make([]float64, len(d.Alpha))
+	sum := 0.0
+	for i, alpha := range d.Alpha {
+		if !(alpha > 0) {
+			panic("invalid argument to Dirichlet")
+		}
+		weights[i] = standardGamma(src, alpha)
+		sum += weights[i]
+	}
+	for i := range weights {
+		weights[i] /= sum
+	}
+	return weights
+}
+
+// A uniformly random point on the unit sphere in Dim dimensions (a random unit
+// vector, or a random direction).  Dim values of Normal{0, 1} are drawn, two
+// words each, and the vector is divided by its length; in the vanishingly rare
+// case that the length is 0, all Dim values are drawn again.
+type UnitSphere struct {
+	Dim int
+}
+
+func (d UnitSphere) Sample(src gorng.Source) []float64 {
+	if d.Dim <= 0 {
+		panic("invalid argument to UnitSphere")
+	}
+	vector := make([]float64, d.Dim)
+	for {
+		length := 0.0
+		for i := range vector {
+			vector[i] = standardNormal(src)
+			length += float64(vector[i] * vector[i])
+		}
+		if length > 0 {
+			length = math.Sqrt(length)
+			for i := range vector {
+				vector[i] /= length
+			}
+			return vector
+		}
+	}
+}
+
+// A uniformly random rotation in Dim dimensions, i.e. a Dim x Dim orthogonal
+// matrix with determinant +1 drawn from the Haar measure on SO(Dim), returned
+// as rows.  The entries of a matrix G are drawn from Normal{0, 1} in row-major
+// order (two words each), and the columns are orthonormalized in order with
+// modified Gram-Schmidt, which yields the Q of a QR decomposition with positive
+// diagonal R and so is Haar-distributed on O(Dim).  If its determinant is -1,
+// the first column is negated.  G has full rank with probability 1; if it
+// doesn't, all of the entries are drawn again.
+type Rotation struct {
+	Dim int
+}
+
+func (d Rotation) Sample(src gorng.Source) [][]float64 {
+	if d.Dim <= 0 {
+		panic("invalid argument to Rotation")
+	}
+	n := d.Dim
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+	}
+
+	for !randomOrthonormal(src, matrix) {
+	}
+	if determinant(matrix) < 0 {
+		for i := range matrix {
+			matrix[i][0] = -matrix[i][0]
+		}
+	}
+	return matrix
+}
+
+// Fills the matrix with Gaussian entries and orthonormalizes its columns,
+// returning false if a column was (numerically) dependent on the previous ones.
+func randomOrthonormal(src gorng.Source, matrix [][]float64) bool {
+	n := len(matrix)
+	for i := range matrix {
+		for j := range matrix[i] {
+			matrix[i][j] = standardNormal(src)
+		}
+	}
+	for j := 0; j < n; j++ {
+		for k := 0; k < j; k++ {
+			dot := 0.0
+			for i := 0; i < n; i++ {
+				dot += float64(matrix[i][j] * matrix[i][k])
+			}
+			for i := 0; i < n; i++ {
+				matrix[i][j] -= float64(dot * matrix[i][k])
+			}
+		}
+		length := 0.0
+		for i := 0; i < n; i++ {
+			length += float64(matrix[i][j] * matrix[i][j])
+		}
+		length = math.Sqrt(length)
+		if !(length > 1e-12) {
+			return false
+		}
+		for i := 0; i < n; i++ {
+			matrix[i][j] /= length
+		}
+	}
+	return true
+}
+
+// The determinant by Gaussian elimination with partial pivoting, on a copy.
+func determinant(matrix [][]float64) float64 {
+	n := len(matrix)
+	work := make([][]float64, n)
+	for i := range matrix {
+		work[i] = append([]float64(nil), matrix[i]...)
+	}
+	det := 1.0
+	for col := 0; col < n; col++ {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(work[row][col]) > math.Abs(work[pivot][col]) {
+				pivot = row
+			}
+		}
+		if work[pivot][col] == 0 {
+			return 0
+		}
+		if pivot != col {
+			work[pivot], work[col] = work[col], work[pivot]
+			det = -det
+		}
+		det *= work[col][col]
+		for row := col + 1; row < n; row++ {
+			factor := work[row][col] / work[col][col]
+			for k := col; k < n; k++ {
+				work[row][k] -= float64(factor * work[col][k])
+			}
+		}
+	}
+	return det
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/dist/multivariate_test.go
+
+package dist_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/dist"
+)
+
+func Test_Multinomial(t *testing.T) {
+	const samples = 20000
+	d := dist.Multinomial{N: 100, Weights: []float64{1, 2, 3, 4}}
+	src := gorng.NewSourceFromString("multinomial")
+
+	sums := make([]float64, len(d.Weights))
+	for range samples {
+		counts := d.Sample(src)
+		total := int64(0)
+		for i, count := range counts {
+			total += count
+			sums[i] += float64(count)
+		}
+		if total != d.N {
+			t.Fatalf("counts %v sum to %d, want %d", counts, total, d.N)
+		}
+	}
+	for i, sum := range sums {
+		p := d.Weights[i] / 10
+		mean := sum / samples
+		stderr := math.Sqrt(100 * p * (1 - p) / samples)
+		if math.Abs(mean-100*p) > 6*stderr {
+			t.Errorf("category %d mean %.3f, want %.3f", i, mean, 100*p)
+		}
+	}
+}
+
+func Test_Dirichlet(t *testing.T) {
+	const samples = 20000
+	alpha := []float64{0.5, 2, 7.5}
+	src := gorng.NewSourceFromString("dirichlet")
+
+	sums := make([]float64, len(alpha))
+	squares := make([]float64, len(alpha))
+	for range samples {
+		weights := dist.Dirichlet{Alpha: alpha}.Sample(src)
+		total := 0.0
+		for i, weight := range weights {
+			total += weight
+			sums[i] += weight
+			squares[i] += weight * weight
+		}
+		if math.Abs(total-1) > 1e-12 {
+			t.Fatalf("weights %v sum to %v", weights, total)
+		}
+	}
+	for i := range alpha {
+		mean := alpha[i] / 10
+		variance := mean * (1 - mean) / 11
+		gotMean := sums[i] / samples
+		gotVariance := squares[i]/samples - gotMean*gotMean
+		if math.Abs(gotMean-mean) > 6*math.Sqrt(variance/samples) {
+			t.Errorf("component %d mean %.4f, want %.4f", i, gotMean, mean)
+		}
+		if math.Abs(gotVariance-variance) > 0.06*variance {
+			t.Errorf("component %d variance %.5f, want %.5f", i, gotVariance, variance)
+		}
+	}
+}
+
+// On the 2-sphere each coordinate is uniform on [-1, 1] (Archimedes' theorem).
+func Test_UnitSphere(t *testing.T) {
+	const samples = 40000
+	const buckets = 20
+	src := gorng.NewSourceFromString("sphere")
+	counts := make([]float64, buckets)
+	for range samples {
+		point := dist.UnitSphere{Dim: 3}.Sample(src)
+		length := math.Sqrt(point[0]*point[0] + point[1]*point[1] + point[2]*point[2])
+		if math.Abs(length-1) > 1e-12 {
+			t.Fatalf("point %v has length %v", point, length)
+		}
+		counts[min(int((point[2]+1)/2*buckets), buckets-1)]++
+	}
+	chi2 := 0.0
+	expected := float64(samples) / buckets
+	for _, count := range counts {
+		chi2 += (count - expected) * (count - expected) / expected
+	}
+	// Critical value for 19 degrees of freedom at p = 0.001.
+	if chi2 > 43.820 {
+		t.Errorf("z coordinates %v are not uniform (chi-square %.2f)", counts, chi2)
+	}
+
+	for _, dim := range []int{1, 2, 10} {
+		if point := (dist.UnitSphere{Dim: dim}).Sample(src); len(point) != dim {
+			t.Errorf("UnitSphere{%d} returned %d coordinates", dim, len(point))
+		}
+	}
+}
+
+// Rotations are orthogonal with determinant 1, and for Haar-uniform rotations
+// in three dimensions the trace has mean 0 and mean square 1.
+func Test_Rotation(t *testing.T) {
+	const samples = 20000
+	src := gorng.NewSourceFromString("rotation")
+	sum, squares := 0.0, 0.0
+	for i := range samples {
+		matrix := dist.Rotation{Dim: 3}.Sample(src)
+		if i < 100 {
+			checkRotation(t, matrix)
+		}
+		trace := matrix[0][0] + matrix[1][1] + matrix[2][2]
+		sum += trace
+		squares += trace * trace
+	}
+	if mean := sum / samples; math.Abs(mean) > 6*math.Sqrt(1.0/samples) {
+		t.Errorf("mean trace %.4f, want 0", mean)
+	}
+	if mean := squares / samples; math.Abs(mean-1) > 0.05 {
+		t.Errorf("mean squared trace %.4f, want 1", mean)
+	}
+
+	for _, dim := range []int{1, 2, 7} {
+		checkRotation(t, dist.Rotation{Dim: dim}.Sample(src))
+	}
+}
+
+func checkRotation(t *testing.T, matrix [][]float64) {
+	t.Helper()
+	n := len(matrix)
+	for j := 0; j < n; j++ {
+		for k := 0; k < n; k++ {
+			dot := 0.0
+			for i := 0; i < n; i++ {
+				dot += matrix[i][j] * matrix[i][k]
+			}
+			want := 0.0
+			if j == k {
+				want = 1
+			}
+			if math.Abs(dot-want) > 1e-9 {
+				t.Fatalf("columns %d and %d have dot product %v", j, k, dot)
+			}
+		}
+	}
+	// The determinant of a 1x1, 2x2 or 3x3 matrix, otherwise trust orthogonality.
+	var det float64
+	switch n {
+	case 1:
+		det = matrix[0][0]
+	case 2:
+		det = matrix[0][0]*matrix[1][1] - matrix[0][1]*matrix[1][0]
+	case 3:
+		det = matrix[0][0]*(matrix[1][1]*matrix[2][2]-matrix[1][2]*matrix[2][1]) -
+			matrix[0][1]*(matrix[1][0]*matrix[2][2]-matrix[1][2]*matrix[2][0]) +
+			matrix[0][2]*(matrix[1][0]*matrix[2][1]-matrix[1][1]*matrix[2][0])
+	default:
+		return
+	}
+	if math.Abs(det-1) > 1e-9 {
+		t.Fatalf("determinant %v, want 1", det)
+	}
+}
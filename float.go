@@ -0,0 +1,141 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/float.go
+
+package gorng
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Full-precision floating-point values.  Instead of scaling a 53-bit integer
+// (which can only produce multiples of 2^-53), the exponent is drawn from a
+// geometric distribution and the mantissa uniformly, following Allen Downey's
+// "Generating Pseudo-random Floating-Point Values" (2007).  Every float in
+// [0, 1) is reachable, each with probability equal to the distance to the next
+// representable float above it, i.e. the result is a uniform real in [0, 1)
+// rounded down to a representable value.
+
+// Returns a value in [0, 1), see float64full() for the algorithm.
+func (rng *ShaRing) Float64() float64 {
+	return float64full(rng)
+}
+
+// Returns a value in [0, 1), see float32full() for the algorithm.
+func (rng *ShaRing) Float32() float32 {
+	return float32full(rng)
+}
+
+// Returns a value in (0, 1].  This is Float64() rounded up instead of down, so
+// the same draw is followed by a step to the next representable float, giving
+// each value a probability equal to the distance from the float below it.
+func (rng *ShaRing) Float64OpenClosed() float64 {
+	return math.Float64frombits(math.Float64bits(float64full(rng)) + 1)
+}
+
+// Returns a value in (0, 1) by drawing Float64() again if it returns zero.
+func (rng *ShaRing) Float64Open() float64 {
+	for {
+		if value := float64full(rng); value != 0 {
+			return value
+		}
+	}
+}
+
+// Returns lo + (hi - lo) * Float64(), drawing again in the rare case that the
+// result is rounded up to hi.  Panics unless lo < hi and both are finite.
+//
+// When hi - lo overflows (e.g. the full range of float64) the bounds are halved
+// first and the result doubled, i.e. 2 * (lo/2 + (hi/2 - lo/2) * Float64()).
+func (rng *ShaRing) Float64Range(lo, hi float64) float64 {
+	if !(lo < hi) || math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+		panic("invalid argument to Float64Range")
+	}
+	span := hi - lo
+	for {
+		var value float64
+		if math.IsInf(span, 1) {
+			value = 2 * (lo/2 + (hi/2-lo/2)*float64full(rng))
+		} else {
+			value = lo + span*float64full(rng)
+		}
+		if value < hi {
+			return value
+		}
+	}
+}
+
+// Counts leading zero bits of the stream to choose the binade [2^-(k+1), 2^-k)
+// with probability 2^-(k+1), then fills the 52-bit mantissa uniformly:
+//
+//	exponent := 1022                  // biased exponent of [0.5, 1)
+//	loop:
+//	    w := src.Uint64()
+//	    if w != 0: exponent -= leading zeros of w; stop
+//	    exponent -= 64
+//	    if exponent <= 0: stop
+//	if exponent < 0: exponent = 0     // subnormal values in [0, 2^-1022)
+//	mantissa := src.Uint64() >> 12    // the 52 most significant bits
+//	return float64 with bits (exponent << 52) | mantissa
+//
+// This consumes two words except with probability 2^-64 per extra word.
+func float64full(src Source) float64 {
+	exponent := 1022
+	for {
+		word := src.Uint64()
+		if word != 0 {
+			exponent -= bits.LeadingZeros64(word)
+			break
+		}
+		exponent -= 64
+		if exponent <= 0 {
+			break
+		}
+	}
+	if exponent < 0 {
+		exponent = 0
+	}
+	mantissa := src.Uint64() >> 12
+	return math.Float64frombits(uint64(exponent)<<52 | mantissa)
+}
+
+// The same as float64full() for the float32 format: the biased exponent starts
+// at 126 and the mantissa is the 23 most significant bits of a Uint64().
+func float32full(src Source) float32 {
+	exponent := 126
+	for {
+		word := src.Uint64()
+		if word != 0 {
+			exponent -= bits.LeadingZeros64(word)
+			break
+		}
+		exponent -= 64
+		if exponent <= 0 {
+			break
+		}
+	}
+	if exponent < 0 {
+		exponent = 0
+	}
+	mantissa := uint32(src.Uint64() >> 41)
+	return math.Float32frombits(uint32(exponent)<<23 | mantissa)
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/float_test.go
+
+package gorng_test
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/sha1"
+)
+
+// The binade [2^-(k+1), 2^-k) should be chosen with probability 2^-(k+1).
+func Test_FloatExponents(t *testing.T) {
+	const samples = 100000
+	const buckets = 12 // the last bucket collects all smaller exponents
+	rng := gorng.NewGenerator([]byte("exponents"))
+
+	counts := make([]float64, buckets)
+	for range samples {
+		value := rng.Float64()
+		if value < 0 || value >= 1 {
+			t.Fatalf("Float64() = %v out of range", value)
+		}
+		_, exp := math.Frexp(value) // value = frac * 2^exp, 0.5 <= frac < 1
+		k := min(-exp, buckets-1)
+		counts[k]++
+	}
+
+	chi2 := 0.0
+	for k, count := range counts {
+		p := math.Ldexp(1, -(k + 1))
+		if k == buckets-1 {
+			p = math.Ldexp(1, -k) // the tail has the remaining probability
+		}
+		expected := samples * p
+		chi2 += (count - expected) * (count - expected) / expected
+	}
+	// Critical value for 11 degrees of freedom at p = 0.001.
+	if chi2 > 31.264 {
+		t.Errorf("exponent counts %v deviate (chi-square %.2f)", counts, chi2)
+	}
+}
+
+// Within a binade the mantissa should be uniform, in its high and low bits.
+func Test_FloatMantissas(t *testing.T) {
+	const samples = 64000
+	rng := gorng.NewGenerator([]byte("mantissas"))
+
+	high := make([]float64, 16)
+	low := make([]float64, 16)
+	for range samples {
+		mantissa := math.Float64bits(rng.Float64()) & (1<<52 - 1)
+		high[mantissa>>48]++
+		low[mantissa&0x0F]++
+	}
+	for _, counts := range [][]float64{high, low} {
+		chi2 := 0.0
+		expected := float64(samples) / 16
+		for _, count := range counts {
+			chi2 += (count - expected) * (count - expected) / expected
+		}
+		// Critical value for 15 degrees of freedom at p = 0.001.
+		if chi2 > 37.697 {
+			t.Errorf("mantissa counts %v deviate (chi-square %.2f)", counts, chi2)
+		}
+	}
+}
+
+// Exhausting the exponent with zero words reaches the subnormal range.
+func Test_FloatEdges(t *testing.T) {
+	// 16 zero words (128 bytes) then a mantissa word in the 7th digest.
+	digests := make([][]byte, 7)
+	for i := range digests {
+		digests[i] = make([]byte, 20)
+	}
+	binary.BigEndian.PutUint64(digests[6][8:], 3<<12)
+	got := gorng.New(&digestHasher{digests: digests}).Float64()
+	if want := 3 * math.SmallestNonzeroFloat64; got != want {
+		t.Errorf("subnormal draw = %v, want %v", got, want)
+	}
+
+	r := gorng.NewGenerator([]byte("intervals"))
+	for range 10000 {
+		if value := r.Float64OpenClosed(); value <= 0 || value > 1 {
+			t.Fatalf("Float64OpenClosed() = %v out of range", value)
+		}
+		if value := r.Float64Open(); value <= 0 || value >= 1 {
+			t.Fatalf("Float64Open() = %v out of range", value)
+		}
+		if value := r.Float64Range(-2.5, 7); value < -2.5 || value >= 7 {
+			t.Fatalf("Float64Range(-2.5, 7) = %v out of range", value)
+		}
+		if value := r.Float32(); value < 0 || value >= 1 {
+			t.Fatalf("Float32() = %v out of range", value)
+		}
+	}
+
+	// The width of the full range overflows to +Inf.
+	negative := 0
+	for range 1000 {
+		value := r.Float64Range(-math.MaxFloat64, math.MaxFloat64)
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			t.Fatalf("Float64Range(full range) = %v", value)
+		}
+		if value < 0 {
+			negative++
+		}
+	}
+	if negative < 400 || negative > 600 {
+		t.Errorf("Float64Range(full range) was negative %d of 1000 times", negative)
+	}
+}
+
+// A Hasher that replays a fixed sequence of digests, for testing rare paths.
+type digestHasher struct {
+	digests [][]byte
+	index   int
+}
+
+func (hasher *digestHasher) Write(message []byte) (int, error) {
+	return len(message), nil
+}
+
+func (hasher *digestHasher) Hash() sha1.Digest {
+	next := fixedDigest(hasher.digests[hasher.index])
+	hasher.index++
+	return next
+}
+
+func (hasher *digestHasher) Reset() {
+	hasher.index = 0
+}
+
+type fixedDigest []byte
+
+func (digest fixedDigest) Bytes() []byte {
+	return digest
+}
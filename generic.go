@@ -0,0 +1,90 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/generic.go
+
+package gorng
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Generic helpers for slices and maps.  Like Rand, they only use the bounded
+// values of uint64n(), so their results depend on nothing but the source.
+
+// Returns one of the items, chosen uniformly with one bounded value.  Panics if
+// there are no items.
+func Choice[T any](src Source, items []T) T {
+	if len(items) == 0 {
+		panic("invalid argument to Choice")
+	}
+	return items[uint64n(src, uint64(len(items)))]
+}
+
+// Shuffles the items in place, in the same way as Rand.Shuffle().
+func Shuffle[T any](src Source, items []T) {
+	NewRand(src).Shuffle(len(items), func(i, j int) {
+		items[i], items[j] = items[j], items[i]
+	})
+}
+
+// Returns k of the items chosen uniformly without replacement, in random order,
+// leaving items unchanged.  It is a partial Fisher-Yates shuffle of a copy: for
+// i from 0 to k-1, position i is swapped with j = i + Uint64N(len(items) - i),
+// so k bounded values are drawn.  Panics if k < 0 or k > len(items).
+func Sample[T any](src Source, items []T, k int) []T {
+	if k < 0 || k > len(items) {
+		panic("invalid argument to Sample")
+	}
+	sample := slices.Clone(items)
+	for i := 0; i < k; i++ {
+		j := i + int(uint64n(src, uint64(len(sample)-i)))
+		sample[i], sample[j] = sample[j], sample[i]
+	}
+	return sample[:k:k]
+}
+
+// Returns the keys of the map in a random order.  Go's map iteration order is
+// itself random, so the keys are sorted before they are shuffled, making the
+// order depend only on the source.
+func ShuffledKeys[K cmp.Ordered, V any](src Source, m map[K]V) []K {
+	keys := sortedKeys(m)
+	Shuffle(src, keys)
+	return keys
+}
+
+// Returns one of the map's keys chosen uniformly, as Choice() of its sorted
+// keys.  Panics if the map is empty.
+func ChoiceKey[K cmp.Ordered, V any](src Source, m map[K]V) K {
+	if len(m) == 0 {
+		panic("invalid argument to ChoiceKey")
+	}
+	return Choice(src, sortedKeys(m))
+}
+
+func sortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/generic_test.go
+
+package gorng_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+func Test_Choice(t *testing.T) {
+	items := []string{"rock", "paper", "scissors"}
+	rng := gorng.NewSourceFromString("choice")
+	want := items[gorng.NewSourceFromString("choice").Uint64N(3)]
+	if got := gorng.Choice(rng, items); got != want {
+		t.Errorf("Choice() = %s, want %s", got, want)
+	}
+
+	counts := make(map[string]int)
+	for range 3000 {
+		counts[gorng.Choice(rng, items)]++
+	}
+	for _, item := range items {
+		if counts[item] < 900 || counts[item] > 1100 {
+			t.Errorf("%s chosen %d times of 3000", item, counts[item])
+		}
+	}
+}
+
+// Shuffle() rearranges the slice the same way that Rand.Perm() does.
+func Test_Shuffle(t *testing.T) {
+	items := []int{10, 11, 12, 13, 14, 15, 16, 17}
+	gorng.Shuffle(gorng.NewSourceFromString("shuffle"), items)
+	perm := gorng.NewRand(gorng.NewSourceFromString("shuffle")).Perm(len(items))
+	for i, from := range perm {
+		if items[i] != 10+from {
+			t.Fatalf("Shuffle() = %v, want the order of Perm() = %v", items, perm)
+		}
+	}
+}
+
+func Test_Sample(t *testing.T) {
+	items := []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+	rng := gorng.NewSourceFromString("sample")
+	for k := 0; k <= len(items); k++ {
+		sample := gorng.Sample(rng, items, k)
+		if len(sample) != k {
+			t.Fatalf("Sample(k=%d) has %d items", k, len(sample))
+		}
+		seen := make(map[int]bool)
+		for _, item := range sample {
+			if seen[item] {
+				t.Errorf("Sample(k=%d) = %v repeats %d", k, sample, item)
+			}
+			seen[item] = true
+		}
+	}
+	if !slices.Equal(items, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("Sample() modified its input: %v", items)
+	}
+
+	// The first item of a sample is uniform.
+	counts := make([]int, len(items))
+	for range 10000 {
+		counts[gorng.Sample(rng, items, 3)[0]]++
+	}
+	for item, count := range counts {
+		if count < 850 || count > 1150 {
+			t.Errorf("item %d first in %d of 10000 samples", item, count)
+		}
+	}
+}
+
+// Maps with the same contents give the same order, regardless of how they were
+// built (and of Go's randomized iteration order).
+func Test_ShuffledKeys(t *testing.T) {
+	one := map[string]int{}
+	two := map[string]int{}
+	names := []string{"ann", "bob", "cat", "dan", "eve", "fay", "gus", "hal"}
+	for i, name := range names {
+		one[name] = i
+		two[names[len(names)-1-i]] = i
+	}
+	want := gorng.ShuffledKeys(gorng.NewSourceFromString("keys"), one)
+	for range 10 {
+		if got := gorng.ShuffledKeys(gorng.NewSourceFromString("keys"), two); !slices.Equal(got, want) {
+			t.Fatalf("ShuffledKeys() = %v, want %v", got, want)
+		}
+	}
+	if got := gorng.ChoiceKey(gorng.NewSourceFromString("keys"), two); !slices.Contains(names, got) {
+		t.Errorf("ChoiceKey() = %q", got)
+	}
+}
+
+func Test_GenericPanics(t *testing.T) {
+	tests := map[string]func(){
+		"Choice":    func() { gorng.Choice[int](gorng.NewSourceSeeded(1), nil) },
+		"Sample":    func() { gorng.Sample(gorng.NewSourceSeeded(1), []int{1}, 2) },
+		"ChoiceKey": func() { gorng.ChoiceKey(gorng.NewSourceSeeded(1), map[int]bool{}) },
+	}
+	for name, fn := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s expected a panic", name)
+				}
+			}()
+			fn()
+		}()
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/hasher.go
+
+package gorng
+
+import (
+	"encoding"
+	"hash"
+	"io"
+)
+
+// The mixing function of a generator.  Each call to Hash() returns the next
+// digest in a chain, i.e. a Hasher must not return the same digest twice in a
+// row, and Write() folds more bytes into the chain.  Both sha1.New() and
+// sha256.New() satisfy this interface, as do hashers adapted by FromHash().
+//
+// For Bookmark(), Rewind(), Derive() and the serialization methods to work,
+// the hasher must also implement encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler.
+type Hasher interface {
+	io.Writer
+	Hash() Digest
+	Reset()
+}
+
+// The same type as sha1.Digest and sha256.Digest.
+type Digest = interface {
+	Bytes() []byte
+}
+
+// Creates a new random number generator from the hasher, after resetting it and
+// writing seed to it, e.g. NewGeneratorWith(sha256.New(), seed).
+func NewGeneratorWith(source Hasher, seed []byte) *ShaRing {
+	source.Reset()
+	source.Write(seed)
+	return newRing(source)
+}
+
+// Adapts a hash.Hash (such as those from crypto/sha256 or crypto/sha512) for use
+// as a generator's Hasher.  Hash() returns the Sum() of everything written so
+// far and then writes that digest back into the hash, which continues the chain
+// so that the next call returns a different digest.  The state can be saved if
+// the hash.Hash implements the encoding.Binary(Un)Marshaler interfaces, which
+// those of the standard library do.
+func FromHash(source hash.Hash) Hasher {
+	return &hashAdapter{source}
+}
+
+type hashAdapter struct {
+	hash hash.Hash
+}
+
+func (adapter *hashAdapter) Write(message []byte) (int, error) {
+	return adapter.hash.Write(message)
+}
+
+func (adapter *hashAdapter) Hash() Digest {
+	sum := adapter.hash.Sum(nil)
+	adapter.hash.Write(sum)
+	return digestBytes(sum)
+}
+
+func (adapter *hashAdapter) Reset() {
+	adapter.hash.Reset()
+}
+
+func (adapter *hashAdapter) MarshalBinary() ([]byte, error) {
+	marshaler, ok := adapter.hash.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, ErrNotMarshalable
+	}
+	return marshaler.MarshalBinary()
+}
+
+func (adapter *hashAdapter) UnmarshalBinary(data []byte) error {
+	unmarshaler, ok := adapter.hash.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return ErrNotMarshalable
+	}
+	return unmarshaler.UnmarshalBinary(data)
+}
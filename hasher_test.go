@@ -0,0 +1,90 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/hasher_test.go
+
+package gorng_test
+
+import (
+	"bytes"
+	gosha256 "crypto/sha256" // for reference implementation
+	"crypto/sha512"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/sha256"
+)
+
+func Test_SHA256Generator(t *testing.T) {
+	seed := []byte("sha-256")
+	want := gosha256.Sum256(seed)
+	rng := gorng.NewGeneratorWith(sha256.New(), seed)
+	if got := rng.NextBits(8 * len(want)); !bytes.Equal(got, want[:]) {
+		t.Errorf("first digest\ngot:  %x\nwant: %x", got, want)
+	}
+
+	// Reads of every width cross the 32-byte digest boundaries consistently.
+	stream := gorng.NewGeneratorWith(sha256.New(), seed).NextBits(8 * 200)
+	rng = gorng.NewGeneratorWith(sha256.New(), seed)
+	var got []byte
+	for len(got) < len(stream) {
+		got = append(got, rng.NextBits(8*3)...)
+		var word [8]byte
+		rng.Read(word[:])
+		got = append(got, word[:]...)
+	}
+	if !bytes.Equal(got[:len(stream)], stream) {
+		t.Errorf("mixed-width reads diverged\ngot:  %x\nwant: %x", got[:len(stream)], stream)
+	}
+
+	bookmark, err := rng.Bookmark()
+	if err != nil {
+		t.Fatalf("Bookmark() error: %s", err)
+	}
+	next := rng.Uint64()
+	restored := gorng.New(sha256.New())
+	if err := restored.Restore(bookmark); err != nil {
+		t.Fatalf("Restore() error: %s", err)
+	}
+	if value := restored.Uint64(); value != next {
+		t.Errorf("restored Uint64() = %x, want %x", value, next)
+	}
+}
+
+// The adapter's chain: each digest is the hash of the seed and every digest
+// before it.
+func Test_HashAdapter(t *testing.T) {
+	seed := []byte("sha-512")
+	first := sha512.Sum512(seed)
+	second := sha512.Sum512(append(bytes.Clone(seed), first[:]...))
+	want := append(first[:], second[:]...)
+
+	rng := gorng.NewGeneratorWith(gorng.FromHash(sha512.New()), seed)
+	if got := rng.NextBits(8 * len(want)); !bytes.Equal(got, want) {
+		t.Errorf("adapted digests\ngot:  %x\nwant: %x", got, want)
+	}
+
+	if err := rng.Rewind(); err != nil {
+		t.Fatalf("Rewind() error: %s", err)
+	}
+	if got := rng.NextBits(8 * 64); !bytes.Equal(got, first[:]) {
+		t.Errorf("after Rewind()\ngot:  %x\nwant: %x", got, first)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/ids.go
+
+package gorng
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/SymbolNotFound/gorng/sha1"
+)
+
+// Random-looking identifiers that are reproducible from the generator's seed.
+// They are not secret: anyone with the seed (or enough of the output) can
+// predict them, so don't use them as session tokens or passwords.
+
+// Crockford's base32 alphabet: digits and capital letters except I, L, O and U.
+const CROCKFORD_ALPHABET = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// Returns length characters chosen uniformly from the alphabet, each with one
+// bounded value Uint64N(number of characters).  The alphabet may contain any
+// (UTF-8) characters; a repeated character is proportionally more likely.
+// Panics if the alphabet is empty or length is negative.
+func (rng *ShaRing) Token(alphabet string, length int) string {
+	if alphabet == "" || length < 0 {
+		panic("invalid argument to Token")
+	}
+	if len(alphabet) == utf8.RuneCountInString(alphabet) {
+		bytes := make([]byte, length)
+		for i := range bytes {
+			bytes[i] = alphabet[uint64n(rng, uint64(len(alphabet)))]
+		}
+		return string(bytes)
+	}
+	runes := []rune(alphabet)
+	var builder strings.Builder
+	for range length {
+		builder.WriteRune(runes[uint64n(rng, uint64(len(runes)))])
+	}
+	return builder.String()
+}
+
+// Returns length characters of Crockford base32, the digits of the big-endian
+// value NextBits(5 * length) from the most significant down, so it consumes
+// ceil(5 * length / 8) bytes of the stream.  Panics if length is negative.
+func (rng *ShaRing) CrockfordID(length int) string {
+	if length < 0 {
+		panic("invalid argument to CrockfordID")
+	}
+	bits := rng.NextBits(5 * length)
+	id := make([]byte, length)
+	// Digit i (from the right) is bits 5i to 5i+4 of the value, counting from
+	// the least significant bit of the last byte.
+	for i := 0; i < length; i++ {
+		position := 5 * i
+		value := uint(bits[len(bits)-1-position/8]) >> (position % 8)
+		if next := len(bits) - 2 - position/8; position%8 > 3 && next >= 0 {
+			value |= uint(bits[next]) << (8 - position%8)
+		}
+		id[length-1-i] = CROCKFORD_ALPHABET[value&0x1f]
+	}
+	return string(id)
+}
+
+// Returns a UUID in the RFC 4122 format of a version 4 (random) UUID, made from
+// the next 16 bytes of the stream with the version and variant bits set, e.g.
+// "1b4e28ba-2fa1-41d2-883f-0016d3cca427".
+func (rng *ShaRing) UUID() string {
+	var uuid sha1.UUID
+	rng.fill(uuid[:])
+	uuid[6] = uuid[6]&0x0f | 0x40 // version 4
+	uuid[8] = uuid[8]&0x3f | 0x80 // variant 10xx
+	return uuid.String()
+}
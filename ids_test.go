@@ -0,0 +1,109 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/ids_test.go
+
+package gorng_test
+
+import (
+	"encoding/hex"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+func Test_Token(t *testing.T) {
+	tests := []struct {
+		alphabet string
+		length   int
+	}{
+		{"01", 64},
+		{"abcdefghijklmnopqrstuvwxyz", 12},
+		{"♠♥♦♣", 10},
+		{"x", 3},
+		{"abc", 0},
+	}
+	for _, tt := range tests {
+		rng := gorng.NewSourceFromString("token")
+		token := rng.Token(tt.alphabet, tt.length)
+		if utf8.RuneCountInString(token) != tt.length {
+			t.Errorf("Token(%q, %d) = %q, wrong length", tt.alphabet, tt.length, token)
+		}
+		// Each character is Uint64N() of the alphabet's size.
+		runes := []rune(tt.alphabet)
+		replay := gorng.NewSourceFromString("token")
+		for i, char := range []rune(token) {
+			if want := runes[replay.Uint64N(uint64(len(runes)))]; char != want {
+				t.Errorf("Token(%q)[%d] = %q, want %q", tt.alphabet, i, char, want)
+			}
+		}
+	}
+}
+
+// A Crockford ID is the base32 representation of the bits it consumed.
+func Test_CrockfordID(t *testing.T) {
+	for _, length := range []int{0, 1, 2, 3, 8, 13, 26} {
+		id := gorng.NewSourceFromString("crockford").CrockfordID(length)
+		bits := gorng.NewSourceFromString("crockford").NextBits(5 * length)
+		value := new(big.Int).SetBytes(bits)
+
+		decoded := new(big.Int)
+		for _, char := range id {
+			digit := strings.IndexRune(gorng.CROCKFORD_ALPHABET, char)
+			if digit < 0 {
+				t.Fatalf("CrockfordID(%d) = %q, not base32", length, id)
+			}
+			decoded.Lsh(decoded, 5)
+			decoded.Or(decoded, big.NewInt(int64(digit)))
+		}
+		if len(id) != length || decoded.Cmp(value) != 0 {
+			t.Errorf("CrockfordID(%d) = %q, want the digits of %x", length, id, bits)
+		}
+	}
+}
+
+func Test_UUID(t *testing.T) {
+	format := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	rng := gorng.NewSourceFromString("uuid")
+	seen := make(map[string]bool)
+	for range 100 {
+		uuid := rng.UUID()
+		if !format.MatchString(uuid) {
+			t.Fatalf("UUID() = %q, not a version 4 UUID", uuid)
+		}
+		if seen[uuid] {
+			t.Fatalf("UUID() repeated %q", uuid)
+		}
+		seen[uuid] = true
+	}
+
+	// The other 122 bits are the next 16 bytes of the stream.
+	uuid := gorng.NewSourceFromString("uuid").UUID()
+	bytes := gorng.NewSourceFromString("uuid").NextBits(128)
+	bytes[6] = bytes[6]&0x0f | 0x40
+	bytes[8] = bytes[8]&0x3f | 0x80
+	if got := strings.ReplaceAll(uuid, "-", ""); got != hex.EncodeToString(bytes) {
+		t.Errorf("UUID() = %s, want the bytes %x", uuid, bytes)
+	}
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/permute.go
+
+package gorng
+
+import (
+	"encoding"
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/SymbolNotFound/gorng/sha1"
+)
+
+// A keyed pseudo-random permutation of [0, n) that is computed lazily, one
+// element at a time, in constant memory; n may be as large as 2^64 - 1.
+//
+// Values are enciphered with a balanced Feistel network over 2h bits, where h is
+// the smallest positive number with 2^2h >= n.  A value x is split into halves
+// L = x >> h and R = x mod 2^h, and each of PERMUTE_ROUNDS rounds computes
+//
+//	L, R = R, L xor F(round, R)
+//	F(round, R) = first 8 bytes of SHA-1(K || round || R) mod 2^h
+//
+// with round as one byte, R as a big-endian uint32 and the result read as a
+// big-endian uint64.  The prefix K is "gorng/permute\x00" || n || key, with n as
+// a big-endian uint64.  Results outside of [0, n) are enciphered again (cycle
+// walking) until they fall within it, at most four times on average.
+//
+// A Permutation is not safe for concurrent use, as the round function reuses
+// one hasher.
+type Permutation struct {
+	n    uint64
+	half int
+	mask uint64
+
+	// SHA-1 state after writing the prefix, so it is only hashed once.
+	prefix []byte
+	work   sha1.Hasher
+}
+
+const PERMUTE_ROUNDS = 8
+const permuteTag = "gorng/permute\x00"
+
+// Creates the permutation of [0, n) for the provided key.  Panics if n == 0.
+func NewPermutation(n uint64, key []byte) *Permutation {
+	if n == 0 {
+		panic("invalid argument to NewPermutation")
+	}
+	half := max(1, (bits.Len64(n-1)+1)/2)
+	perm := &Permutation{
+		n:    n,
+		half: half,
+		mask: (uint64(1) << half) - 1,
+		work: sha1.New(),
+	}
+
+	var size [8]byte
+	binary.BigEndian.PutUint64(size[:], n)
+	perm.work.Write([]byte(permuteTag))
+	perm.work.Write(size[:])
+	perm.work.Write(key)
+	perm.prefix, _ = marshalHasher(perm.work)
+	return perm
+}
+
+// Creates a permutation of [0, n) keyed by the next 20 bytes of the stream,
+// which advances this generator.  Panics if n == 0.
+func (rng *ShaRing) Permutation(n uint64) *Permutation {
+	var key [sha1.DIGEST_BYTES]byte
+	rng.fill(key[:])
+	return NewPermutation(n, key[:])
+}
+
+// The size of the permutation's domain.
+func (perm *Permutation) Len() uint64 {
+	return perm.n
+}
+
+// Returns the element at position i of the permutation.  Panics if i >= Len().
+func (perm *Permutation) Permute(i uint64) uint64 {
+	if i >= perm.n {
+		panic("invalid argument to Permute")
+	}
+	for {
+		left, right := i>>perm.half, i&perm.mask
+		for round := 0; round < PERMUTE_ROUNDS; round++ {
+			left, right = right, left^perm.round(round, right)
+		}
+		i = left<<perm.half | right
+		if i < perm.n {
+			return i
+		}
+	}
+}
+
+// Returns the position of element j, so that Permute(Inverse(j)) == j.  Panics
+// if j >= Len().
+func (perm *Permutation) Inverse(j uint64) uint64 {
+	if j >= perm.n {
+		panic("invalid argument to Inverse")
+	}
+	for {
+		left, right := j>>perm.half, j&perm.mask
+		for round := PERMUTE_ROUNDS - 1; round >= 0; round-- {
+			left, right = right^perm.round(round, left), left
+		}
+		j = left<<perm.half | right
+		if j < perm.n {
+			return j
+		}
+	}
+}
+
+// The Feistel round function F(round, value), see Permutation.
+func (perm *Permutation) round(round int, value uint64) uint64 {
+	var bytes [5]byte
+	bytes[0] = byte(round)
+	binary.BigEndian.PutUint32(bytes[1:], uint32(value))
+	perm.work.(encoding.BinaryUnmarshaler).UnmarshalBinary(perm.prefix)
+	perm.work.Write(bytes[:])
+	return binary.BigEndian.Uint64(perm.work.Hash().Bytes()) & perm.mask
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/permute_test.go
+
+package gorng_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+func Test_PermutationBijective(t *testing.T) {
+	for _, n := range []uint64{1, 2, 3, 4, 5, 10, 255, 256, 1000, 4097} {
+		perm := gorng.NewPermutation(n, []byte("bijective"))
+		seen := make([]bool, n)
+		for i := uint64(0); i < n; i++ {
+			j := perm.Permute(i)
+			if j >= n {
+				t.Fatalf("n=%d: Permute(%d) = %d, out of range", n, i, j)
+			}
+			if seen[j] {
+				t.Fatalf("n=%d: Permute(%d) = %d, repeated", n, i, j)
+			}
+			seen[j] = true
+			if inverse := perm.Inverse(j); inverse != i {
+				t.Fatalf("n=%d: Inverse(%d) = %d, want %d", n, j, inverse, i)
+			}
+		}
+	}
+}
+
+func Test_PermutationHugeDomain(t *testing.T) {
+	tests := []uint64{1<<40 + 7, 1 << 63, math.MaxUint64}
+	for _, n := range tests {
+		perm := gorng.NewPermutation(n, []byte("huge"))
+		for _, i := range []uint64{0, 1, 2, n / 3, n / 2, n - 2, n - 1} {
+			j := perm.Permute(i)
+			if j >= n {
+				t.Errorf("n=%d: Permute(%d) = %d, out of range", n, i, j)
+			}
+			if inverse := perm.Inverse(j); inverse != i {
+				t.Errorf("n=%d: Inverse(Permute(%d)) = %d", n, i, inverse)
+			}
+		}
+	}
+}
+
+// Golden vectors for ports, and a check that the key and size both matter.
+func Test_PermutationVectors(t *testing.T) {
+	perm := gorng.NewPermutation(1000000, []byte("levels"))
+	want := []uint64{983068, 501366, 39239, 224355, 170448, 907274}
+	for i, expected := range want {
+		if got := perm.Permute(uint64(i)); got != expected {
+			t.Errorf("Permute(%d) = %d, want %d", i, got, expected)
+		}
+	}
+
+	other := gorng.NewPermutation(1000000, []byte("levels!"))
+	larger := gorng.NewPermutation(1000001, []byte("levels"))
+	same := 0
+	for i := uint64(0); i < 100; i++ {
+		if other.Permute(i) == perm.Permute(i) || larger.Permute(i) == perm.Permute(i) {
+			same++
+		}
+	}
+	if same > 2 {
+		t.Errorf("%d of 100 values unchanged by a different key or size", same)
+	}
+
+	rng := gorng.NewGenerator([]byte("seed"))
+	first, second := rng.Permutation(52), rng.Permutation(52)
+	if first.Len() != 52 || first.Permute(0) == second.Permute(0) && first.Permute(1) == second.Permute(1) {
+		t.Error("successive Permutation() calls should use different keys")
+	}
+}
+
+func Test_PermutationPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected Permute(n) to panic")
+		}
+	}()
+	gorng.NewPermutation(10, nil).Permute(10)
+}
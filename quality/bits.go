@@ -0,0 +1,170 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/quality/bits.go
+
+package quality
+
+import "math"
+
+// Frequency (monobit) test, NIST 2.1: the difference between the numbers of
+// ones and zeros, S, gives the p-value erfc(|S| / sqrt(2n)).
+func Monobit(seq Bits) Result {
+	if seq.n == 0 {
+		return skipped("monobit")
+	}
+	sum := float64(2*seq.ones(0, seq.n) - seq.n)
+	statistic := math.Abs(sum) / math.Sqrt(float64(seq.n))
+	return Result{"monobit", statistic, math.Erfc(statistic / math.Sqrt2)}
+}
+
+// Frequency test within a block, NIST 2.2: the proportion of ones in each of
+// the n/m whole blocks of m bits, compared with 1/2 by a chi-square test.
+func BlockFrequency(seq Bits, m int) Result {
+	blocks := 0
+	if m > 0 {
+		blocks = seq.n / m
+	}
+	if blocks == 0 {
+		return skipped("block frequency")
+	}
+	chi2 := 0.0
+	for i := 0; i < blocks; i++ {
+		pi := float64(seq.ones(i*m, (i+1)*m)) / float64(m)
+		chi2 += (pi - 0.5) * (pi - 0.5)
+	}
+	chi2 *= 4 * float64(m)
+	return Result{"block frequency", chi2, igamc(float64(blocks)/2, chi2/2)}
+}
+
+// Runs test, NIST 2.3: the number of runs of identical bits compared with its
+// expectation given the proportion of ones.  If that proportion is too far
+// from 1/2 for the test to apply (by 2/sqrt(n) or more), the p-value is 0, as
+// the sequence already fails the monobit test.
+func Runs(seq Bits) Result {
+	if seq.n < 2 {
+		return skipped("runs")
+	}
+	n := float64(seq.n)
+	pi := float64(seq.ones(0, seq.n)) / n
+	if math.Abs(pi-0.5) >= 2/math.Sqrt(n) {
+		return Result{"runs", 0, 0}
+	}
+	runs := 1
+	for i := 1; i < seq.n; i++ {
+		if seq.Bit(i) != seq.Bit(i-1) {
+			runs++
+		}
+	}
+	expected := 2 * n * pi * (1 - pi)
+	statistic := float64(runs)
+	p := math.Erfc(math.Abs(statistic-expected) / (2 * math.Sqrt(2*n) * pi * (1 - pi)))
+	return Result{"runs", statistic, p}
+}
+
+// Parameters of the longest run test for each minimum length, NIST 2.4.4: the
+// block size, the longest runs counted in the first and last categories, and
+// the probability of each category.
+var longestRunParameters = []struct {
+	minimum, block, low, high int
+	probabilities             []float64
+}{
+	{750000, 10000, 10, 16, []float64{0.0882, 0.2092, 0.2483, 0.1933, 0.1208, 0.0675, 0.0727}},
+	{6272, 128, 4, 9, []float64{0.1174, 0.2430, 0.2493, 0.1752, 0.1027, 0.1124}},
+	{128, 8, 1, 4, []float64{0.2148, 0.3672, 0.2305, 0.1875}},
+}
+
+// Test for the longest run of ones in a block, NIST 2.4: the longest run in
+// each block, in categories from "low or shorter" to "high or longer", compared
+// with the expected frequencies by a chi-square test.  The block size is 8,
+// 128 or 10000 bits depending on the length, which must be at least 128.
+func LongestRun(seq Bits) Result {
+	for _, parameters := range longestRunParameters {
+		if seq.n < parameters.minimum {
+			continue
+		}
+		counts := make([]float64, len(parameters.probabilities))
+		blocks := seq.n / parameters.block
+		for i := 0; i < blocks; i++ {
+			longest, run := 0, 0
+			for j := i * parameters.block; j < (i+1)*parameters.block; j++ {
+				run = (run + 1) * seq.Bit(j)
+				longest = max(longest, run)
+			}
+			category := min(max(longest, parameters.low), parameters.high) - parameters.low
+			counts[category]++
+		}
+		chi2 := 0.0
+		for i, probability := range parameters.probabilities {
+			expected := float64(blocks) * probability
+			chi2 += (counts[i] - expected) * (counts[i] - expected) / expected
+		}
+		degrees := float64(len(counts) - 1)
+		return Result{"longest run", chi2, igamc(degrees/2, chi2/2)}
+	}
+	return skipped("longest run")
+}
+
+// Serial test, NIST 2.11: the frequencies of all overlapping m-bit patterns
+// (wrapping around), through the statistics psi^2 for m, m-1 and m-2 bits.
+// Returns the results for the first and second differences of psi^2.  Needs
+// m >= 2; NIST suggests m < log2(n) - 2.
+func Serial(seq Bits, m int) (Result, Result) {
+	if m < 2 || m >= 31 || seq.n == 0 || seq.n < 1<<m {
+		return skipped("serial"), skipped("serial (second difference)")
+	}
+	psi := func(m int) float64 {
+		if m <= 0 {
+			return 0
+		}
+		sum := 0.0
+		for _, count := range seq.patterns(m) {
+			sum += float64(count) * float64(count)
+		}
+		return sum*math.Ldexp(1, m)/float64(seq.n) - float64(seq.n)
+	}
+	psi0, psi1, psi2 := psi(m), psi(m-1), psi(m-2)
+	delta := psi0 - psi1
+	delta2 := psi0 - 2*psi1 + psi2
+	return Result{"serial", delta, igamc(math.Ldexp(1, m-2), delta/2)},
+		Result{"serial (second difference)", delta2, igamc(math.Ldexp(1, m-3), delta2/2)}
+}
+
+// Approximate entropy test, NIST 2.12: compares the frequencies of overlapping
+// m-bit and (m+1)-bit patterns (wrapping around).  Needs m >= 1; NIST suggests
+// m < log2(n) - 5.
+func ApproximateEntropy(seq Bits, m int) Result {
+	if m < 1 || m >= 30 || seq.n == 0 || seq.n < 1<<(m+1) {
+		return skipped("approximate entropy")
+	}
+	phi := func(m int) float64 {
+		sum := 0.0
+		for _, count := range seq.patterns(m) {
+			if count > 0 {
+				pi := float64(count) / float64(seq.n)
+				sum += pi * math.Log(pi)
+			}
+		}
+		return sum
+	}
+	entropy := phi(m) - phi(m+1)
+	chi2 := 2 * float64(seq.n) * (math.Ln2 - entropy)
+	return Result{"approximate entropy", chi2, igamc(math.Ldexp(1, m-1), chi2/2)}
+}
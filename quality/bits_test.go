@@ -0,0 +1,94 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/quality/bits_test.go
+
+package quality_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng/quality"
+)
+
+// The 100-bit sequence of the examples in NIST SP 800-22, sections 2.1 to 2.3.
+const nist100 = "11001001000011111101101010100010001000010110100011" +
+	"00001000110100110001001100011001100010100010111000"
+
+// The 128-bit sequence of the example in section 2.4.
+const nist128 = "11001100000101010110110001001100111000000000001001" +
+	"00110101010001000100111101011010000000110101111100" +
+	"1100111001101101100010110010"
+
+func bits(t *testing.T, text string) quality.Bits {
+	seq, err := quality.FromString(text)
+	if err != nil {
+		t.Fatalf("FromString(%q) error: %s", text, err)
+	}
+	return seq
+}
+
+// The p-values of the worked examples, which NIST gives to six places.
+func Test_NISTExamples(t *testing.T) {
+	serial, delta2 := quality.Serial(bits(t, "0011011101"), 3)
+	tests := []struct {
+		result   quality.Result
+		expected float64
+	}{
+		{quality.Monobit(bits(t, "1011010101")), 0.527089},
+		{quality.Monobit(bits(t, nist100)), 0.109599},
+		{quality.BlockFrequency(bits(t, "0110011010"), 3), 0.801252},
+		{quality.BlockFrequency(bits(t, nist100), 10), 0.706438},
+		{quality.Runs(bits(t, "1001101011")), 0.147232},
+		{quality.Runs(bits(t, nist100)), 0.500798},
+		// NIST prints 0.180609, but its chi-square of 4.882605 gives 0.180598
+		// (by Q(3/2, x) = erfc(sqrt(x)) + 2 sqrt(x/pi) exp(-x)).
+		{quality.LongestRun(bits(t, nist128)), 0.180598},
+		{serial, 0.808792},
+		{delta2, 0.670320},
+		{quality.ApproximateEntropy(bits(t, "0100110101"), 3), 0.261961},
+		{quality.ApproximateEntropy(bits(t, nist100), 2), 0.235301},
+	}
+	for _, tt := range tests {
+		if math.Abs(tt.result.PValue-tt.expected) > 1e-6 {
+			t.Errorf("%s: p-value %.6f, want %.6f", tt.result.Name, tt.result.PValue, tt.expected)
+		}
+	}
+}
+
+func Test_BitsSkipped(t *testing.T) {
+	short := bits(t, "0110")
+	serial, _ := quality.Serial(short, 3)
+	for _, result := range []quality.Result{
+		quality.Monobit(bits(t, "")),
+		quality.BlockFrequency(short, 8),
+		quality.LongestRun(short),
+		serial,
+		quality.ApproximateEntropy(short, 3),
+	} {
+		if !result.Skipped() || result.Pass(0.01) {
+			t.Errorf("%s: expected a skipped test, got %v", result.Name, result)
+		}
+	}
+	if _, err := quality.FromString("0120"); err != quality.ErrInvalidBits {
+		t.Errorf("FromString(\"0120\") error = %v", err)
+	}
+}
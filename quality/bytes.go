@@ -0,0 +1,115 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/quality/bytes.go
+
+package quality
+
+import (
+	"math"
+	"slices"
+)
+
+// Chi-square test of the frequencies of the 256 byte values, with 255 degrees
+// of freedom.  Needs at least 5 bytes per value on average.
+func ByteChiSquare(data []byte) Result {
+	if len(data) < 5*256 {
+		return skipped("byte chi-square")
+	}
+	var counts [256]float64
+	for _, b := range data {
+		counts[b]++
+	}
+	return chiSquare("byte chi-square", counts[:], float64(len(data))/256)
+}
+
+// Chi-square test that values are uniform in [0, n), e.g. the output of a
+// bounded integer method, with n - 1 degrees of freedom.  Needs n <= 2^24 and at
+// least 5 values per possible value on average.
+func Uniform(values []uint64, n uint64) Result {
+	if n < 2 || n > 1<<24 || uint64(len(values)) < 5*n {
+		return skipped("uniform")
+	}
+	counts := make([]float64, n)
+	for _, value := range values {
+		if value >= n {
+			return Result{"uniform", math.Inf(1), 0}
+		}
+		counts[value]++
+	}
+	return chiSquare("uniform", counts, float64(len(values))/float64(n))
+}
+
+func chiSquare(name string, counts []float64, expected float64) Result {
+	chi2 := 0.0
+	for _, count := range counts {
+		chi2 += (count - expected) * (count - expected) / expected
+	}
+	degrees := float64(len(counts) - 1)
+	return Result{name, chi2, igamc(degrees/2, chi2/2)}
+}
+
+// Parameters of the birthday spacings test: BIRTHDAYS birthdays of 24 bits
+// each (3 bytes, big-endian) in a year of 2^24 days, so that the number of
+// repeated spacings is approximately Poisson with mean BIRTHDAYS^3 / 2^26 = 2.
+const BIRTHDAYS = 512
+const birthdayBytes = 3
+
+// Birthday spacings test (Marsaglia, as formulated by L'Ecuyer and Simard): for
+// each group of BIRTHDAYS birthdays, sort them and count the spacings between
+// consecutive birthdays that equal an earlier spacing.  The total over all
+// groups is compared with a Poisson distribution by a two-sided test, and is
+// the statistic reported.  Uses as many whole groups of 1536 bytes as there are.
+func BirthdaySpacings(data []byte) Result {
+	groups := len(data) / (BIRTHDAYS * birthdayBytes)
+	if groups == 0 {
+		return skipped("birthday spacings")
+	}
+	birthdays := make([]int, BIRTHDAYS)
+	spacings := make([]int, BIRTHDAYS-1)
+	repeats := 0
+	for group := 0; group < groups; group++ {
+		for i := range birthdays {
+			b := data[(group*BIRTHDAYS+i)*birthdayBytes:]
+			birthdays[i] = int(b[0])<<16 | int(b[1])<<8 | int(b[2])
+		}
+		slices.Sort(birthdays)
+		for i := range spacings {
+			spacings[i] = birthdays[i+1] - birthdays[i]
+		}
+		slices.Sort(spacings)
+		for i := 1; i < len(spacings); i++ {
+			if spacings[i] == spacings[i-1] {
+				repeats++
+			}
+		}
+	}
+
+	// Two-sided: twice the smaller tail, P(X <= k) = Q(k+1, mean) and
+	// P(X >= k) = P(k, mean) for a Poisson X.
+	mean := float64(groups) * math.Pow(BIRTHDAYS, 3) / (1 << 26)
+	k := float64(repeats)
+	lower := igamc(k+1, mean)
+	upper := 1.0
+	if repeats > 0 {
+		upper = igam(k, mean)
+	}
+	return Result{"birthday spacings", k, min(1, 2*min(lower, upper))}
+}
@@ -0,0 +1,216 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/quality/quality.go
+
+// Statistical tests of random-looking data, each reporting a p-value: the
+// probability that truly random data would give a result at least as extreme.
+// A very small p-value (say below 0.001) is evidence of a flaw, and p-values of
+// a good generator are themselves uniform in [0, 1], so across many tests a
+// few small ones are expected.
+//
+// The bit-level tests follow NIST SP 800-22 rev. 1a, "A Statistical Test Suite
+// for Random and Pseudorandom Number Generators for Cryptographic Applications",
+// and reproduce the p-values of its worked examples.  They read bits from each
+// byte most significant first, the same order as ShaRing.NextBits().  A test
+// reports a p-value of NaN when its input is too short for it.
+package quality
+
+import (
+	"errors"
+	"math"
+	"math/bits"
+)
+
+// The outcome of one test: its test statistic and the p-value of it.
+type Result struct {
+	Name      string
+	Statistic float64
+	PValue    float64
+}
+
+// Whether the test was run, and its p-value is at least alpha.
+func (result Result) Pass(alpha float64) bool {
+	return result.PValue >= alpha
+}
+
+// Whether the input was too short for the test to be run.
+func (result Result) Skipped() bool {
+	return math.IsNaN(result.PValue)
+}
+
+func skipped(name string) Result {
+	return Result{name, math.NaN(), math.NaN()}
+}
+
+// Runs the full battery on the data with default parameters that suit its
+// length, see each test for the parameters chosen.
+func Battery(data []byte) []Result {
+	seq := FromBytes(data)
+	n := seq.Len()
+	log2n := bits.Len(uint(n)) - 1
+
+	results := []Result{
+		Monobit(seq),
+		BlockFrequency(seq, max(128, n/100)),
+		Runs(seq),
+		LongestRun(seq),
+	}
+	serial, delta2 := Serial(seq, min(16, log2n-3))
+	results = append(results, serial, delta2,
+		ApproximateEntropy(seq, min(10, log2n-6)),
+		ByteChiSquare(data),
+		BirthdaySpacings(data),
+	)
+	return results
+}
+
+// A sequence of bits, read from bytes most significant bit first.
+type Bits struct {
+	bytes []byte
+	n     int
+}
+
+// All of the bits of the data, 8 * len(data) of them.
+func FromBytes(data []byte) Bits {
+	return Bits{data, 8 * len(data)}
+}
+
+var ErrInvalidBits = errors.New("quality: bits must be written as 0 and 1")
+
+// Parses a sequence written as the characters 0 and 1, e.g. from a paper.
+func FromString(text string) (Bits, error) {
+	seq := Bits{make([]byte, (len(text)+7)/8), len(text)}
+	for i, char := range []byte(text) {
+		switch char {
+		case '1':
+			seq.bytes[i/8] |= 0x80 >> (i % 8)
+		case '0':
+		default:
+			return Bits{}, ErrInvalidBits
+		}
+	}
+	return seq, nil
+}
+
+func (seq Bits) Len() int {
+	return seq.n
+}
+
+// The i'th bit, 0 or 1.
+func (seq Bits) Bit(i int) int {
+	return int(seq.bytes[i/8]>>(7-i%8)) & 1
+}
+
+// The number of one bits in [from, to).
+func (seq Bits) ones(from, to int) int {
+	count := 0
+	for ; from < to && from%8 != 0; from++ {
+		count += seq.Bit(from)
+	}
+	for ; from+8 <= to; from += 8 {
+		count += bits.OnesCount8(seq.bytes[from/8])
+	}
+	for ; from < to; from++ {
+		count += seq.Bit(from)
+	}
+	return count
+}
+
+// Counts of each m-bit pattern over the n overlapping windows, wrapping around
+// from the end of the sequence to its start.
+func (seq Bits) patterns(m int) []int {
+	counts := make([]int, 1<<m)
+	if m == 0 {
+		counts[0] = seq.n
+		return counts
+	}
+	mask := 1<<m - 1
+	window := 0
+	for i := 0; i < m-1; i++ {
+		window = window<<1 | seq.Bit(i)
+	}
+	for i := 0; i < seq.n; i++ {
+		window = (window<<1 | seq.Bit((i+m-1)%seq.n)) & mask
+		counts[window]++
+	}
+	return counts
+}
+
+// The regularized upper incomplete gamma function Q(a, x), which NIST calls
+// igamc, by its series for x < a+1 and a continued fraction otherwise
+// (Numerical Recipes, section 6.2).
+func igamc(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	if math.IsInf(x, 1) {
+		return 0
+	}
+	if x < a+1 {
+		return 1 - igam(a, x)
+	}
+	lgamma, _ := math.Lgamma(a)
+	const tiny = 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i < 1000; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		delta := d * c
+		h *= delta
+		if math.Abs(delta-1) < 1e-15 {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lgamma) * h
+}
+
+// The regularized lower incomplete gamma function P(a, x) = 1 - Q(a, x).
+func igam(a, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= a+1 {
+		return 1 - igamc(a, x)
+	}
+	lgamma, _ := math.Lgamma(a)
+	term := 1 / a
+	sum := term
+	for n := 1; n < 10000; n++ {
+		term *= x / (a + float64(n))
+		sum += term
+		if math.Abs(term) < math.Abs(sum)*1e-16 {
+			break
+		}
+	}
+	return sum * math.Exp(-x+a*math.Log(x)-lgamma)
+}
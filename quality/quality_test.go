@@ -0,0 +1,101 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/quality/quality_test.go
+
+package quality_test
+
+import (
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/quality"
+)
+
+// The generator's output passes the battery.  The seed is fixed, so this is
+// deterministic; a different seed fails a test at level 0.001 now and then.
+func Test_BatteryShaRing(t *testing.T) {
+	data := make([]byte, 1<<20)
+	gorng.NewSourceFromString("quality").Read(data)
+	results := quality.Battery(data)
+	if len(results) != 9 {
+		t.Errorf("Battery() ran %d tests, want 9", len(results))
+	}
+	for _, result := range results {
+		if result.Skipped() || !result.Pass(0.001) {
+			t.Errorf("%s: statistic %g, p-value %g", result.Name, result.Statistic, result.PValue)
+		}
+	}
+}
+
+// Obviously flawed data fails.
+func Test_BatteryFlawed(t *testing.T) {
+	counter := make([]byte, 1<<16)
+	for i := range counter {
+		counter[i] = byte(i)
+	}
+	biased := make([]byte, 1<<16)
+	gorng.NewSourceFromString("biased").Read(biased)
+	for i := range biased {
+		biased[i] |= byte(i) & 0x01
+	}
+	for name, data := range map[string][]byte{"counter": counter, "biased": biased} {
+		failed := 0
+		for _, result := range quality.Battery(data) {
+			if !result.Skipped() && !result.Pass(0.001) {
+				failed++
+			}
+		}
+		if failed == 0 {
+			t.Errorf("%s data passed every test", name)
+		}
+	}
+}
+
+// The bounded integer methods are uniform, including bounds that need
+// rejections and ones that don't divide 2^64.
+func Test_UniformBounded(t *testing.T) {
+	rng := gorng.NewSourceFromString("bounded")
+	tests := []struct {
+		name   string
+		n      uint64
+		sample func() uint64
+	}{
+		{"Uint64N(3)", 3, func() uint64 { return rng.Uint64N(3) }},
+		{"Uint64N(1000)", 1000, func() uint64 { return rng.Uint64N(1000) }},
+		{"Uint32N(7)", 7, func() uint64 { return uint64(rng.Uint32N(7)) }},
+		{"IntN(52)", 52, func() uint64 { return uint64(rng.IntN(52)) }},
+		{"Int64Range(-5, 5)", 10, func() uint64 { return uint64(rng.Int64Range(-5, 5) + 5) }},
+		{"Uint64Range(1<<40, 1<<40+6)", 6, func() uint64 { return rng.Uint64Range(1<<40, 1<<40+6) - 1<<40 }},
+	}
+	for _, tt := range tests {
+		values := make([]uint64, 100*tt.n)
+		for i := range values {
+			values[i] = tt.sample()
+		}
+		if result := quality.Uniform(values, tt.n); !result.Pass(0.001) {
+			t.Errorf("%s: chi-square %g, p-value %g", tt.name, result.Statistic, result.PValue)
+		}
+	}
+
+	if result := quality.Uniform([]uint64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, 2); result.Pass(0.001) {
+		t.Errorf("values out of range passed, p-value %g", result.PValue)
+	}
+}
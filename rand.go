@@ -0,0 +1,188 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/rand.go
+
+package gorng
+
+import (
+	"encoding/binary"
+	randv1 "math/rand"
+	randv2 "math/rand/v2"
+)
+
+// A *ShaRing can be passed directly to math/rand/v2.New(...).
+var _ randv2.Source = (*ShaRing)(nil)
+
+// Adapts a generator to the Source64 interface of math/rand (v1).
+func NewSource64(rng *ShaRing) randv1.Source64 {
+	return source64{rng}
+}
+
+type source64 struct {
+	*ShaRing
+}
+
+// Returns the 63 most significant bits of the next Uint64().
+func (source source64) Int63() int64 {
+	return int64(source.Uint64() >> 1)
+}
+
+// Reseeds the generator with the seed as eight big-endian bytes, see Reseed().
+// Note that a generator created from a digest is reset to the standard initial
+// hash value, not to that digest.
+func (source source64) Seed(seed int64) {
+	var bytes [8]byte
+	binary.BigEndian.PutUint64(bytes[:], uint64(seed))
+	source.Reseed(bytes[:])
+}
+
+// Provides the convenience methods of math/rand/v2.Rand on top of any Source,
+// but with each of the algorithms fixed here so that the values produced for
+// a given seed do not change between Go releases (which stdlib reserves the
+// right to do) and can be matched by implementations in other languages.
+//
+// Every method draws whole values from Source.Uint64(); where a method's doc
+// does not mention consumption, it uses exactly one Uint64() per call.
+type Rand struct {
+	src Source
+}
+
+func NewRand(src Source) *Rand {
+	return &Rand{src}
+}
+
+func (r *Rand) Uint64() uint64 {
+	return r.src.Uint64()
+}
+
+// The 32 most significant bits of the next Uint64().
+func (r *Rand) Uint32() uint32 {
+	return uint32(r.src.Uint64() >> 32)
+}
+
+// A non-negative int64, the 63 most significant bits of the next Uint64().
+func (r *Rand) Int64() int64 {
+	return int64(r.src.Uint64() >> 1)
+}
+
+// A non-negative int32, the 31 most significant bits of the next Uint64().
+func (r *Rand) Int32() int32 {
+	return int32(r.src.Uint64() >> 33)
+}
+
+// A non-negative int, the same as Int64() on 64-bit platforms and its lowest 31
+// bits on 32-bit platforms.
+func (r *Rand) Int() int {
+	return int(uint(r.src.Uint64()>>1) << 1 >> 1)
+}
+
+// Returns a uniform value in [0, n), see uint64n() for the algorithm and its
+// consumption of the source.  Panics if n == 0.
+func (r *Rand) Uint64N(n uint64) uint64 {
+	if n == 0 {
+		panic("invalid argument to Uint64N")
+	}
+	return uint64n(r.src, n)
+}
+
+// The same algorithm (and consumption) as Uint64N.  Panics if n == 0.
+func (r *Rand) Uint32N(n uint32) uint32 {
+	if n == 0 {
+		panic("invalid argument to Uint32N")
+	}
+	return uint32(uint64n(r.src, uint64(n)))
+}
+
+// The same algorithm (and consumption) as Uint64N.  Panics if n <= 0.
+func (r *Rand) Int64N(n int64) int64 {
+	if n <= 0 {
+		panic("invalid argument to Int64N")
+	}
+	return int64(uint64n(r.src, uint64(n)))
+}
+
+// The same algorithm (and consumption) as Uint64N.  Panics if n <= 0.
+func (r *Rand) Int32N(n int32) int32 {
+	if n <= 0 {
+		panic("invalid argument to Int32N")
+	}
+	return int32(uint64n(r.src, uint64(n)))
+}
+
+// The same algorithm (and consumption) as Uint64N.  Panics if n <= 0.
+func (r *Rand) IntN(n int) int {
+	if n <= 0 {
+		panic("invalid argument to IntN")
+	}
+	return int(uint64n(r.src, uint64(n)))
+}
+
+// Returns a uniform value in [lo, hi), see int64range().  Panics if lo >= hi.
+func (r *Rand) Int64Range(lo, hi int64) int64 {
+	if lo >= hi {
+		panic("invalid argument to Int64Range")
+	}
+	return int64range(r.src, lo, hi)
+}
+
+// Returns a uniform value in [lo, hi), see int64range().  Panics if lo >= hi.
+func (r *Rand) IntRange(lo, hi int) int {
+	if lo >= hi {
+		panic("invalid argument to IntRange")
+	}
+	return int(int64range(r.src, int64(lo), int64(hi)))
+}
+
+// A value in [0, 1) from the 53 most significant bits of the next Uint64().
+func (r *Rand) Float64() float64 {
+	return float64(r.src.Uint64()>>11) * 0x1p-53
+}
+
+// A value in [0, 1) from the 24 most significant bits of the next Uint64().
+func (r *Rand) Float32() float32 {
+	return float32(r.src.Uint64()>>40) * 0x1p-24
+}
+
+// Returns a permutation of the integers [0, n), the identity permutation as
+// rearranged by Shuffle().
+func (r *Rand) Perm(n int) []int {
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	r.Shuffle(n, func(i, j int) {
+		perm[i], perm[j] = perm[j], perm[i]
+	})
+	return perm
+}
+
+// A Fisher-Yates shuffle: for i from n-1 down to 1, swap(i, j) is called with
+// j = IntN(i+1), so it consumes n-1 bounded values (swap may be called with
+// i == j).  Panics if n < 0.
+func (r *Rand) Shuffle(n int, swap func(i, j int)) {
+	if n < 0 {
+		panic("invalid argument to Shuffle")
+	}
+	for i := n - 1; i > 0; i-- {
+		j := int(uint64n(r.src, uint64(i+1)))
+		swap(i, j)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/rand_test.go
+
+package gorng_test
+
+import (
+	randv1 "math/rand"
+	randv2 "math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+func Test_StdlibAdapters(t *testing.T) {
+	expected := gorng.NewGenerator([]byte("adapters")).Uint64()
+
+	v2 := randv2.New(gorng.NewGenerator([]byte("adapters")))
+	if got := v2.Uint64(); got != expected {
+		t.Errorf("math/rand/v2 Uint64() = %x, want %x", got, expected)
+	}
+
+	v1 := randv1.New(gorng.NewSource64(gorng.NewGenerator([]byte("adapters"))))
+	if got := v1.Int63(); got != int64(expected>>1) {
+		t.Errorf("math/rand Int63() = %x, want %x", got, expected>>1)
+	}
+
+	// Seeding is equivalent to writing the big-endian seed to a fresh hasher.
+	source := gorng.NewSource64(gorng.NewGenerator([]byte("replaced")))
+	source.Uint64()
+	source.Seed(0x0102030405060708)
+	want := gorng.NewGenerator([]byte{1, 2, 3, 4, 5, 6, 7, 8}).Uint64()
+	if got := source.Uint64(); got != want {
+		t.Errorf("after Seed(), Uint64() = %x, want %x", got, want)
+	}
+}
+
+// Checks the rejection threshold and consumption of Uint64N with a sequence of
+// chosen values.  For n = 3, 2^64 mod 3 == 1 so only a zero low product retries.
+func Test_RandUint64N(t *testing.T) {
+	source := &sequenceSource{values: []uint64{
+		0,                  // lo == 0 < 1, rejected
+		1 << 63,            // hi == 1
+		0xFFFFFFFFFFFFFFFF, // hi == 2
+		0x5555555555555555, // hi == 0, lo == 0xFFFF...FF
+	}}
+	r := gorng.NewRand(source)
+	for i, want := range []uint64{1, 2, 0} {
+		if got := r.Uint64N(3); got != want {
+			t.Errorf("Uint64N(3) #%d = %d, want %d", i, got, want)
+		}
+	}
+	if source.index != 4 {
+		t.Errorf("expected 4 values consumed, got %d", source.index)
+	}
+}
+
+func Test_RandRanges(t *testing.T) {
+	r := gorng.NewRand(gorng.NewGenerator([]byte("ranges")))
+	counts := make([]int, 7)
+	for range 7000 {
+		counts[r.IntN(7)]++
+		if f := r.Float64(); f < 0 || f >= 1 {
+			t.Fatalf("Float64() = %v out of range", f)
+		}
+		if v := r.Int32N(1000); v < 0 || v >= 1000 {
+			t.Fatalf("Int32N(1000) = %d out of range", v)
+		}
+		if r.Int64() < 0 || r.Int32() < 0 || r.Int() < 0 {
+			t.Fatal("expected non-negative values")
+		}
+	}
+	for i, count := range counts {
+		if count < 850 || count > 1150 {
+			t.Errorf("IntN(7) produced %d %d times out of 7000", i, count)
+		}
+	}
+}
+
+func Test_RandPerm(t *testing.T) {
+	perm := gorng.NewRand(gorng.NewGenerator([]byte("perm"))).Perm(52)
+	again := gorng.NewRand(gorng.NewGenerator([]byte("perm"))).Perm(52)
+	if !slices.Equal(perm, again) {
+		t.Errorf("same seed produced different permutations\n%v\n%v", perm, again)
+	}
+	sorted := slices.Clone(perm)
+	slices.Sort(sorted)
+	for i := range sorted {
+		if sorted[i] != i {
+			t.Fatalf("not a permutation: %v", perm)
+		}
+	}
+}
+
+// Replays a fixed sequence of values, for checking exact consumption.
+type sequenceSource struct {
+	values []uint64
+	index  int
+}
+
+func (source *sequenceSource) Uint64() uint64 {
+	value := source.values[source.index]
+	source.index++
+	return value
+}
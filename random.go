@@ -24,7 +24,10 @@ package gorng
 
 import (
 	"encoding/binary"
+	"io"
+	"sync"
 
+	"github.com/SymbolNotFound/gorng/safe"
 	"github.com/SymbolNotFound/gorng/sha1"
 )
 
@@ -32,55 +35,177 @@ type Source interface {
 	Uint64() uint64
 }
 
+// A pseudo-random number generator based on a chain of digests, SHA-1 unless
+// another Hasher is provided.  Each digest is the hash of the generator's state
+// after the previous digest was mixed into it, and the bytes of those digests
+// are consumed in order, i.e. the generator produces a stream of bytes that all
+// of its methods draw from.  Digests may be of any (non-zero) size.
+//
+// A ShaRing is not safe for concurrent use, see Channel() for a goroutine-safe
+// way of sharing one generator.
 type ShaRing struct {
-	rng    sha1.Hasher
+	rng    Hasher
 	offset int
-	digest sha1.Digest
+	digest Digest
+
+	// The hasher's state when the generator was seeded, for Rewind().
+	origin []byte
+
+	// Guards the generator while channels are producing values from it.
+	mu       sync.Mutex
+	channels map[int]safe.SafeRandom
 }
 
 // Creates a new random number generator using the provided Hasher source.
 // If a nil value is passed for the source then the default hasher will be used.
-func New(source sha1.Hasher) *ShaRing {
+func New(source Hasher) *ShaRing {
 	if source == nil {
 		source = sha1.New()
 	}
-	return &ShaRing{source, 0, nil}
+	return newRing(source)
+}
+
+// Creates a new random number generator seeded with the provided bytes.  The
+// first digest of the generator is the SHA-1 hash of seed.
+func NewGenerator(seed []byte) *ShaRing {
+	source := sha1.New()
+	source.Write(seed)
+	return newRing(source)
 }
 
+// Creates a new random number generator seeded with one or more integers.  The
+// values are written to the hasher as consecutive 8-byte big-endian integers,
+// so NewSourceSeeded(1, 2) is equivalent to NewSourceFromBytes() of the 16 bytes
+// 0x00..01 0x00..02.  SHA-1 includes the message length, so different numbers
+// of values always produce different sequences.
 func NewSourceSeeded(seed uint64, more ...uint64) *ShaRing {
 	source := sha1.New()
-	size := 2 + (2 * len(more))
+	size := 8 * (1 + len(more))
 	bytes := make([]byte, size)
 	binary.BigEndian.PutUint64(bytes[0:], seed)
 	for i := range more {
 		binary.BigEndian.PutUint64(bytes[8*(i+1):], more[i])
 	}
 	source.Write(bytes)
-	return &ShaRing{source, 0, nil}
+	return newRing(source)
 }
 
-func NewSourceDigest(digest sha1.Digest) *ShaRing {
+// Creates a new random number generator that continues the chain of digests
+// from the provided one, i.e. the digest is used as the hasher's chain value.
+func NewSourceDigest(digest Digest) *ShaRing {
 	source := sha1.NewFromDigest(digest)
-	return &ShaRing{source, 0, nil}
+	return newRing(source)
+}
+
+// Wraps a seeded hasher, remembering its initial state when it is marshalable.
+func newRing(source Hasher) *ShaRing {
+	rng := &ShaRing{rng: source}
+	rng.origin, _ = marshalHasher(source)
+	return rng
 }
 
+// Returns the next eight bytes of the stream as a big-endian uint64, reading
+// into the next digest when fewer than eight bytes of the current one remain.
+//
+// This is a break from the earliest versions of gorng, which hashed again on
+// every call and returned the first eight bytes of each digest; the same seed
+// gives a different sequence from the second value on (see the README).
 func (rng *ShaRing) Uint64() uint64 {
-	var next uint64
-	switch rng.offset {
-	case 0:
-		rng.digest = rng.rng.Hash()
-		next = binary.BigEndian.Uint64(rng.digest.Bytes())
-	case 4, 8:
-		next = binary.BigEndian.Uint64(rng.digest.Bytes())
-		rng.offset += 8
-	case 12:
-		next = binary.BigEndian.Uint64(rng.digest.Bytes())
-		rng.offset = 0
-	case 16:
-		next = uint64(binary.BigEndian.Uint32(rng.digest.Bytes()[16:])) << 32
-		rng.digest = rng.rng.Hash()
-		next += uint64(binary.BigEndian.Uint32(rng.digest.Bytes()))
-		rng.offset = 4
+	// Read directly from the current digest when it has more than eight bytes
+	// left, otherwise let fill() take care of crossing into the next digest.
+	if rng.offset != 0 {
+		if bytes := rng.digest.Bytes(); rng.offset+8 < len(bytes) {
+			next := binary.BigEndian.Uint64(bytes[rng.offset:])
+			rng.offset += 8
+			return next
+		}
+	}
+	var bytes [8]byte
+	rng.fill(bytes[:])
+	return binary.BigEndian.Uint64(bytes[:])
+}
+
+// Generates an arbitrary number of bits, returned as a big-endian byte slice
+// with the most significant bits in the zero'th element.  When bits is not a
+// multiple of eight, the unused high bits of the zero'th element are zeroed.
+//
+// Exactly ceil(bits / 8) bytes are consumed from the stream.
+func (rng *ShaRing) NextBits(bits int) []byte {
+	if bits <= 0 {
+		return []byte{}
+	}
+	bytes := make([]byte, (bits+7)/8)
+	rng.fill(bytes)
+	if partial := bits & 0x07; partial != 0 {
+		bytes[0] &= byte(1<<partial) - 1
+	}
+	return bytes
+}
+
+// Implements io.Reader, filling buffer with the next len(buffer) bytes of the
+// stream.  Every byte of each digest is used exactly once, in order, so reading
+// eight bytes and decoding them big-endian gives the same value as Uint64(), and
+// reading n bytes gives the same bytes as NextBits(8 * n).  Never fails, and
+// the only allocation is the hasher's for each new digest.
+func (rng *ShaRing) Read(buffer []byte) (int, error) {
+	rng.fill(buffer)
+	return len(buffer), nil
+}
+
+var _ io.Reader = (*ShaRing)(nil)
+
+// Fixed-width integer helpers, each consumes exactly as many bytes from the
+// stream as its width and interprets them as a big-endian value.  The signed
+// variants cover the full range of the type, including negative values.
+
+func (rng *ShaRing) NextUint8() uint8 {
+	var bytes [1]byte
+	rng.fill(bytes[:])
+	return bytes[0]
+}
+
+func (rng *ShaRing) NextUint16() uint16 {
+	var bytes [2]byte
+	rng.fill(bytes[:])
+	return binary.BigEndian.Uint16(bytes[:])
+}
+
+func (rng *ShaRing) NextUint32() uint32 {
+	var bytes [4]byte
+	rng.fill(bytes[:])
+	return binary.BigEndian.Uint32(bytes[:])
+}
+
+func (rng *ShaRing) NextUint64() uint64 {
+	return rng.Uint64()
+}
+
+func (rng *ShaRing) NextInt8() int8 {
+	return int8(rng.NextUint8())
+}
+
+func (rng *ShaRing) NextInt16() int16 {
+	return int16(rng.NextUint16())
+}
+
+func (rng *ShaRing) NextInt32() int32 {
+	return int32(rng.NextUint32())
+}
+
+func (rng *ShaRing) NextInt64() int64 {
+	return int64(rng.Uint64())
+}
+
+// Copies the next len(buffer) bytes of the stream into buffer, computing the
+// next digest in the chain whenever the current one has been fully consumed.
+func (rng *ShaRing) fill(buffer []byte) {
+	for len(buffer) > 0 {
+		if rng.offset == 0 {
+			rng.digest = rng.rng.Hash()
+		}
+		bytes := rng.digest.Bytes()
+		count := copy(buffer, bytes[rng.offset:])
+		buffer = buffer[count:]
+		rng.offset = (rng.offset + count) % len(bytes)
 	}
-	return next
 }
@@ -0,0 +1,157 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/random_test.go
+
+package gorng_test
+
+import (
+	"bytes"
+	gosha1 "crypto/sha1" // for reference implementation
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// The first digest of a seeded generator is the standard SHA-1 of the seed.
+func Test_FirstDigest(t *testing.T) {
+	seed := []byte("gorng")
+	expected := gosha1.Sum(seed)
+
+	rng := gorng.NewGenerator(seed)
+	got := rng.NextBits(8 * len(expected))
+	if !bytes.Equal(got, expected[:]) {
+		t.Errorf("first digest mismatch\ngot:  %x\nwant: %x", got, expected)
+	}
+}
+
+// All of the methods draw from the same stream of bytes, regardless of width.
+func Test_StreamConsistency(t *testing.T) {
+	seed := []byte("stream")
+	stream := gorng.NewGenerator(seed).NextBits(8 * 200)
+
+	rng := gorng.NewGenerator(seed)
+	var got []byte
+	for i := 0; len(got) < len(stream); i++ {
+		switch i % 5 {
+		case 0:
+			got = binary.BigEndian.AppendUint64(got, rng.Uint64())
+		case 1:
+			got = append(got, rng.NextUint8())
+		case 2:
+			got = binary.BigEndian.AppendUint32(got, uint32(rng.NextInt32()))
+		case 3:
+			got = binary.BigEndian.AppendUint16(got, rng.NextUint16())
+		case 4:
+			got = append(got, rng.NextBits(24)...)
+		}
+	}
+	if !bytes.Equal(got[:len(stream)], stream) {
+		t.Errorf("mixed-width reads diverged from the byte stream\ngot:  %x\nwant: %x",
+			got[:len(stream)], stream)
+	}
+}
+
+func Test_Reader(t *testing.T) {
+	seed := []byte("reader")
+	stream := gorng.NewGenerator(seed).NextBits(8 * 1000)
+
+	var buffer bytes.Buffer
+	rng := gorng.NewGenerator(seed)
+	if _, err := io.CopyN(&buffer, rng, 999); err != nil {
+		t.Fatalf("error copying from generator: %s", err)
+	}
+	var word [8]byte
+	if _, err := io.ReadFull(rng, word[:1]); err != nil {
+		t.Fatalf("error reading from generator: %s", err)
+	}
+	buffer.Write(word[:1])
+	if !bytes.Equal(buffer.Bytes(), stream) {
+		t.Error("bytes read differ from the stream")
+	}
+
+	rng = gorng.NewGenerator(seed)
+	rng.Read(word[:3])
+	rng.Read(word[:])
+	if got, want := binary.BigEndian.Uint64(word[:]), binary.BigEndian.Uint64(stream[3:]); got != want {
+		t.Errorf("Read() of 8 bytes = %x, want %x", got, want)
+	}
+}
+
+func Test_NextBits(t *testing.T) {
+	tests := []struct {
+		name   string
+		bits   int
+		length int
+		mask   byte
+	}{
+		{"zero", 0, 0, 0},
+		{"one", 1, 1, 0x01},
+		{"byte", 8, 1, 0xFF},
+		{"int32", 32, 4, 0xFF},
+		{"readme", 289, 37, 0x01},
+		{"large", 1337, 168, 0x01},
+	}
+	rng := gorng.NewGenerator([]byte("bits"))
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for range 100 {
+				value := rng.NextBits(tt.bits)
+				if len(value) != tt.length {
+					t.Fatalf("NextBits(%d) has length %d, want %d",
+						tt.bits, len(value), tt.length)
+				}
+				if len(value) > 0 && value[0]&^tt.mask != 0 {
+					t.Fatalf("NextBits(%d) has unused high bits set: %x", tt.bits, value)
+				}
+			}
+		})
+	}
+}
+
+func Test_Channels(t *testing.T) {
+	rng := gorng.NewGenerator([]byte("channels"))
+	small := rng.Channel(32)
+	large := rng.Channel(1337)
+	if rng.Channel(32) != small {
+		t.Error("expected the same channel for the same size")
+	}
+
+	for range 50 {
+		select {
+		case value := <-small:
+			if len(value) != 4 {
+				t.Errorf("expected 4 bytes, got %d", len(value))
+			}
+		case value := <-large:
+			if len(value) != 168 {
+				t.Errorf("expected 168 bytes, got %d", len(value))
+			}
+		}
+	}
+
+	rng.Close()
+	for range small {
+	}
+	for range large {
+	}
+}
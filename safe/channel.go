@@ -22,14 +22,23 @@
 
 package safe
 
+import (
+	"encoding/binary"
+	"sync"
+)
+
 type SafeRandom interface {
 	Channel() <-chan []byte
 	Close()
 }
 
-func New(source Source, bits uint8) SafeRandom {
+func New(source Source, bits int) SafeRandom {
 	channel := make(chan []byte)
-	saferandom := randchan{source, bits, channel}
+	saferandom := &randchan{
+		source:  source,
+		bits:    bits,
+		channel: channel,
+		done:    make(chan struct{})}
 	saferandom.start()
 	return saferandom
 }
@@ -43,18 +52,35 @@ func New(source Source, bits uint8) SafeRandom {
 // each simulator, to obtain higher throughput on a multiprocessor system.
 type randchan struct {
 	source  Source
-	bits    uint8
+	bits    int
 	channel chan []byte
+	done    chan struct{}
+	closing sync.Once
 }
 
-func (rng randchan) start() {
+// Starts the goroutine that produces values until Close() is called.  A value
+// is generated before waiting for a reader, so that one is always ready.
+func (rng *randchan) start() {
+	go func() {
+		defer close(rng.channel)
+		for {
+			next := rng.source.NextBits(rng.bits)
+			select {
+			case rng.channel <- next:
+			case <-rng.done:
+				return
+			}
+		}
+	}()
 }
 
-func (rng randchan) Channel() <-chan []byte {
+func (rng *randchan) Channel() <-chan []byte {
 	return rng.channel
 }
 
-func (rng randchan) Close() {
+// Stops the producer, which closes the channel.  Safe to call more than once.
+func (rng *randchan) Close() {
+	rng.closing.Do(func() { close(rng.done) })
 }
 
 // A source of random numbers, modeled after math/rand.Source.
@@ -63,9 +89,12 @@ type RandSource interface {
 }
 
 // An extension of math/rand.Source that also generates byte slices.
+//
+// NextBits returns ceil(bits / 8) bytes in big-endian order (the zero'th byte
+// holds the most significant bits), with any unused high bits set to zero.
 type Source interface {
 	RandSource
-	Bytes(size uint8) []byte
+	NextBits(bits int) []byte
 }
 
 // Convenience method for extending a math/rand.Source for compatibility.
@@ -77,31 +106,20 @@ type extendedSource struct {
 	RandSource
 }
 
-func (source extendedSource) Bytes(bits uint8) []byte {
-	if bits == 0 {
+// Draws ceil(bits / 64) values from the source, writing each one big-endian
+// and discarding the low-order bytes of the last one that aren't needed.
+func (source extendedSource) NextBits(bits int) []byte {
+	if bits <= 0 {
 		return []byte{}
 	}
-	countBytes := bits / 8
-	if bits&0x07 > 0 {
-		countBytes += 1
+	bytes := make([]byte, (bits+7)/8)
+	var word [8]byte
+	for offset := 0; offset < len(bytes); offset += 8 {
+		binary.BigEndian.PutUint64(word[:], source.RandSource.Uint64())
+		copy(bytes[offset:], word[:])
 	}
-	bytes := make([]byte, 0, countBytes)
-	offset := 0
-
-	for bits > 0 {
-		next := source.RandSource.Uint64()
-		for i := 0; i < 8; i++ {
-			if bits < 8 {
-				mask := uint64(1<<bits) - 1
-				bytes[offset+i] = byte(next & mask)
-				bits = 0
-				break
-			}
-			bytes[offset+i] = byte(next & 0xFF)
-			next >>= 8
-			bits >>= 3
-		}
+	if partial := bits & 0x07; partial != 0 {
+		bytes[0] &= byte(1<<partial) - 1
 	}
-
 	return bytes
 }
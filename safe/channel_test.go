@@ -21,3 +21,68 @@
 // github.com:SymbolNotFound/gorng/sha1/hash.go
 
 package safe_test
+
+import (
+	"bytes"
+	"math/rand/v2"
+	"sync"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng/safe"
+)
+
+func Test_ExtendSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		bits     int
+		expected []byte
+	}{
+		{"empty", 0, []byte{}},
+		{"nibble", 4, []byte{0x01}},
+		{"byte", 8, []byte{0x01}},
+		{"partial", 12, []byte{0x01, 0x23}},
+		{"word", 64, []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD, 0xEF}},
+		{"multiword", 72, []byte{
+			0x01, 0x23, 0x45, 0x67, 0x89, 0xAB, 0xCD, 0xEF, 0x01}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := safe.ExtendSource(constantSource(0x0123456789ABCDEF))
+			got := source.NextBits(tt.bits)
+			if !bytes.Equal(got, tt.expected) {
+				t.Errorf("NextBits(%d) = %x, want %x", tt.bits, got, tt.expected)
+			}
+		})
+	}
+}
+
+func Test_ConcurrentReaders(t *testing.T) {
+	source := safe.ExtendSource(rand.NewPCG(1, 2))
+	saferandom := safe.New(source, 24)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if value := <-saferandom.Channel(); len(value) != 3 {
+					t.Errorf("expected 3 bytes, got %d", len(value))
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	saferandom.Close()
+	saferandom.Close()
+	for range saferandom.Channel() {
+		// drain any value produced before the close was observed
+	}
+}
+
+type constantSource uint64
+
+func (source constantSource) Uint64() uint64 {
+	return uint64(source)
+}
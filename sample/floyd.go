@@ -0,0 +1,52 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/sample/floyd.go
+
+package sample
+
+import "github.com/SymbolNotFound/gorng"
+
+// Returns k distinct integers chosen uniformly from [0, n) with Floyd's
+// algorithm (Bentley, "Programming Pearls: A sample of brilliance", 1987):
+// for j from n-k to n-1, t = Uint64N(j+1) is added to the sample, or j if t is
+// already in it.  Exactly k bounded values are drawn, and memory is O(k) however
+// large n is.
+//
+// Every k-subset is equally likely, but the order of the result is not uniform
+// (n-1 can only come last, for example); shuffle it if the order matters.
+// Panics if k > n.
+func Floyd(src gorng.Source, k, n uint64) []uint64 {
+	if k > n {
+		panic("invalid argument to Floyd")
+	}
+	rng := gorng.NewRand(src)
+	chosen := make(map[uint64]struct{}, k)
+	sample := make([]uint64, 0, k)
+	for j := n - k; j < n; j++ {
+		t := rng.Uint64N(j + 1)
+		if _, ok := chosen[t]; ok {
+			t = j
+		}
+		chosen[t] = struct{}{}
+		sample = append(sample, t)
+	}
+	return sample
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/sample/floyd_test.go
+
+package sample_test
+
+import (
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/sample"
+)
+
+func Test_Floyd(t *testing.T) {
+	tests := []struct{ k, n uint64 }{
+		{0, 0}, {0, 5}, {1, 1}, {5, 5}, {3, 10}, {10, 1 << 62},
+	}
+	src := gorng.NewSourceFromString("floyd")
+	for _, tt := range tests {
+		chosen := sample.Floyd(src, tt.k, tt.n)
+		if uint64(len(chosen)) != tt.k {
+			t.Errorf("Floyd(%d, %d) returned %d values", tt.k, tt.n, len(chosen))
+		}
+		seen := make(map[uint64]bool)
+		for _, value := range chosen {
+			if value >= tt.n || seen[value] {
+				t.Errorf("Floyd(%d, %d) = %v, out of range or repeated", tt.k, tt.n, chosen)
+			}
+			seen[value] = true
+		}
+	}
+}
+
+// Each of the C(5, 2) = 10 subsets is equally likely.
+func Test_FloydUniform(t *testing.T) {
+	const trials = 20000
+	src := gorng.NewSourceFromString("subsets")
+	counts := make(map[[2]uint64]float64)
+	for range trials {
+		chosen := sample.Floyd(src, 2, 5)
+		counts[[2]uint64{min(chosen[0], chosen[1]), max(chosen[0], chosen[1])}]++
+	}
+	if len(counts) != 10 {
+		t.Fatalf("%d distinct subsets, want 10", len(counts))
+	}
+	expected, chi2 := trials/10.0, 0.0
+	for _, count := range counts {
+		chi2 += (count - expected) * (count - expected) / expected
+	}
+	// Critical value for 9 degrees of freedom at p = 0.001.
+	if chi2 > 27.877 {
+		t.Errorf("subset counts %v (chi-square %.2f)", counts, chi2)
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/sample/reservoir.go
+
+// Sampling without replacement: reservoirs that keep k items chosen uniformly
+// (or by weight) from a stream of unknown length, and Floyd's algorithm for k
+// distinct integers out of n.
+//
+// As in the dist package, every sampler documents how many 64-bit words it
+// takes from its gorng.Source, so that the same seed selects the same items in
+// any port.  Bounded integers are gorng.Rand's unbiased Uint64N(), and uniform
+// reals in (0, 1) are (x>>11 + 0.5) * 2^-53 for a word x.
+package sample
+
+import (
+	"math"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// Algorithm R (Waterman, in Knuth vol. 2): the first k items fill the
+// reservoir, then the i'th item (counting from 0) draws j = Uint64N(i+1) and
+// replaces item j if j < k.  One bounded value per item after the first k.
+type Reservoir[T any] struct {
+	rng   *gorng.Rand
+	k     int
+	seen  uint64
+	items []T
+}
+
+// Creates an empty reservoir of size k.  Panics if k < 1.
+func NewReservoir[T any](src gorng.Source, k int) *Reservoir[T] {
+	if k < 1 {
+		panic("invalid argument to NewReservoir")
+	}
+	return &Reservoir[T]{rng: gorng.NewRand(src), k: k}
+}
+
+func (reservoir *Reservoir[T]) Add(item T) {
+	if len(reservoir.items) < reservoir.k {
+		reservoir.items = append(reservoir.items, item)
+	} else if j := reservoir.rng.Uint64N(reservoir.seen + 1); j < uint64(reservoir.k) {
+		reservoir.items[j] = item
+	}
+	reservoir.seen++
+}
+
+// The number of items added so far.
+func (reservoir *Reservoir[T]) Seen() uint64 {
+	return reservoir.seen
+}
+
+// A copy of the sample, min(k, Seen()) items.
+func (reservoir *Reservoir[T]) Items() []T {
+	return append([]T(nil), reservoir.items...)
+}
+
+// Algorithm L (Li, "Reservoir-Sampling Algorithms of Time Complexity
+// O(n(1 + log(N/n)))", 1994), which gives the same distribution as Algorithm R
+// but computes how many items to skip, so it takes O(k log(N/k)) words rather
+// than one per item.  When the reservoir fills, and after each replacement,
+//
+//	w    = w * exp(log(u1) / k)          (w starts at 1)
+//	next = next + floor(log(u2) / log1p(-w)) + 1
+//
+// for uniform u1 then u2 in (0, 1), where next is the index of the next item to
+// be placed (the first item after the reservoir fills is at index k).  That item
+// replaces item Uint64N(k) of the reservoir.  log1p(-w) is ln(1 - w) computed
+// without first rounding 1 - w, and next saturates at the largest uint64.
+type SkipReservoir[T any] struct {
+	rng   *gorng.Rand
+	k     int
+	seen  uint64
+	next  uint64
+	w     float64
+	items []T
+}
+
+// Creates an empty reservoir of size k.  Panics if k < 1.
+func NewSkipReservoir[T any](src gorng.Source, k int) *SkipReservoir[T] {
+	if k < 1 {
+		panic("invalid argument to NewSkipReservoir")
+	}
+	return &SkipReservoir[T]{rng: gorng.NewRand(src), k: k, w: 1}
+}
+
+func (reservoir *SkipReservoir[T]) Add(item T) {
+	switch {
+	case len(reservoir.items) < reservoir.k:
+		reservoir.items = append(reservoir.items, item)
+		if len(reservoir.items) == reservoir.k {
+			reservoir.next = uint64(reservoir.k) - 1
+			reservoir.skip()
+		}
+	case reservoir.seen == reservoir.next:
+		reservoir.items[reservoir.rng.Uint64N(uint64(reservoir.k))] = item
+		reservoir.skip()
+	}
+	reservoir.seen++
+}
+
+// Updates w and moves next past the items that won't be sampled.
+func (reservoir *SkipReservoir[T]) skip() {
+	reservoir.w *= math.Exp(math.Log(uniformOpen(reservoir.rng)) / float64(reservoir.k))
+	skipped := math.Floor(math.Log(uniformOpen(reservoir.rng)) / math.Log1p(-reservoir.w))
+	if skipped >= math.MaxUint64-float64(reservoir.next) {
+		reservoir.next = math.MaxUint64
+		return
+	}
+	reservoir.next += uint64(skipped) + 1
+}
+
+// The number of items added so far.
+func (reservoir *SkipReservoir[T]) Seen() uint64 {
+	return reservoir.seen
+}
+
+// A copy of the sample, min(k, Seen()) items.
+func (reservoir *SkipReservoir[T]) Items() []T {
+	return append([]T(nil), reservoir.items...)
+}
+
+// A uniform value in (0, 1) from the 53 most significant bits of one word.
+func uniformOpen(src gorng.Source) float64 {
+	return (float64(src.Uint64()>>11) + 0.5) * 0x1p-53
+}
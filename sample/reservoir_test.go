@@ -0,0 +1,106 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/sample/reservoir_test.go
+
+package sample_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/sample"
+)
+
+type reservoir interface {
+	Add(item int)
+	Items() []int
+}
+
+func Test_ReservoirUniform(t *testing.T) {
+	const trials, items, k = 20000, 10, 3
+	tests := []struct {
+		name string
+		make func(src gorng.Source) reservoir
+	}{
+		{"Algorithm R", func(src gorng.Source) reservoir { return sample.NewReservoir[int](src, k) }},
+		{"Algorithm L", func(src gorng.Source) reservoir { return sample.NewSkipReservoir[int](src, k) }},
+	}
+	for _, tt := range tests {
+		src := gorng.NewSourceFromString(tt.name)
+		counts := make([]float64, items)
+		for range trials {
+			r := tt.make(src)
+			for i := range items {
+				r.Add(i)
+			}
+			chosen := r.Items()
+			if len(chosen) != k {
+				t.Fatalf("%s: %d items sampled, want %d", tt.name, len(chosen), k)
+			}
+			for _, item := range chosen {
+				counts[item]++
+			}
+		}
+		// Each item is in the sample with probability k / items.
+		expected := float64(trials) * k / items
+		chi2 := 0.0
+		for _, count := range counts {
+			chi2 += (count - expected) * (count - expected) / expected
+		}
+		// Critical value for 9 degrees of freedom at p = 0.001 (conservative, as
+		// inclusions within one sample are negatively correlated).
+		if chi2 > 27.877 {
+			t.Errorf("%s: inclusion counts %v (chi-square %.2f)", tt.name, counts, chi2)
+		}
+	}
+}
+
+func Test_ReservoirShortStream(t *testing.T) {
+	r := sample.NewSkipReservoir[string](gorng.NewSourceFromString("short"), 5)
+	r.Add("a")
+	r.Add("b")
+	if items := r.Items(); !reflect.DeepEqual(items, []string{"a", "b"}) || r.Seen() != 2 {
+		t.Errorf("Items() = %v, Seen() = %d", items, r.Seen())
+	}
+}
+
+// Algorithm L only draws when the reservoir fills and at each replacement.
+func Test_SkipReservoirConsumption(t *testing.T) {
+	counting := &countingSource{src: gorng.NewSourceFromString("skip")}
+	r := sample.NewSkipReservoir[int](counting, 10)
+	for i := range 1000000 {
+		r.Add(i)
+	}
+	if counting.count > 1000 {
+		t.Errorf("%d words drawn for a million items", counting.count)
+	}
+}
+
+type countingSource struct {
+	src   gorng.Source
+	count int
+}
+
+func (source *countingSource) Uint64() uint64 {
+	source.count++
+	return source.src.Uint64()
+}
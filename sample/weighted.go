@@ -0,0 +1,164 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/sample/weighted.go
+
+package sample
+
+import (
+	"container/heap"
+	"math"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// Weighted sampling without replacement after Efraimidis and Spirakis,
+// "Weighted random sampling with a reservoir" (2006): each item gets the key
+// u^(1/weight) for a uniform u in (0, 1), and the sample is the k items with the
+// largest keys.  Keys are kept as their logarithms, log(u) / weight, which
+// orders them the same way without underflowing for small weights.
+//
+// Items with a weight of zero are never sampled and consume nothing.  Negative,
+// infinite or NaN weights cause a panic.
+
+// A-Res: one uniform value per item with a positive weight.
+type WeightedReservoir[T any] struct {
+	src  gorng.Source
+	keys keyHeap[T]
+}
+
+// Creates an empty weighted reservoir of size k.  Panics if k < 1.
+func NewWeightedReservoir[T any](src gorng.Source, k int) *WeightedReservoir[T] {
+	if k < 1 {
+		panic("invalid argument to NewWeightedReservoir")
+	}
+	return &WeightedReservoir[T]{src, keyHeap[T]{k: k}}
+}
+
+func (reservoir *WeightedReservoir[T]) Add(item T, weight float64) {
+	if !validWeight(weight) {
+		panic("invalid argument to Add")
+	}
+	if weight == 0 {
+		return
+	}
+	key := math.Log(uniformOpen(reservoir.src)) / weight
+	if len(reservoir.keys.items) < reservoir.keys.k {
+		heap.Push(&reservoir.keys, keyed[T]{item, key})
+	} else if key > reservoir.keys.items[0].key {
+		reservoir.keys.items[0] = keyed[T]{item, key}
+		heap.Fix(&reservoir.keys, 0)
+	}
+}
+
+// A copy of the sample, in no particular (but a deterministic) order.
+func (reservoir *WeightedReservoir[T]) Items() []T {
+	return reservoir.keys.values()
+}
+
+// A-ExpJ, which samples from the same distribution as A-Res but jumps over the
+// items that won't enter the reservoir.  Once it is full, with T the smallest
+// key, a uniform u1 in (0, 1) gives the total weight to skip, log(u1) / log(T).
+// The item at which the skipped weight runs out replaces the smallest key, with
+// its key drawn from (T^weight, 1) as t + (1 - t) * u2 for another uniform u2,
+// and the next jump is drawn from the new smallest key.
+type JumpReservoir[T any] struct {
+	src  gorng.Source
+	keys keyHeap[T]
+	jump float64
+}
+
+// Creates an empty weighted reservoir of size k.  Panics if k < 1.
+func NewJumpReservoir[T any](src gorng.Source, k int) *JumpReservoir[T] {
+	if k < 1 {
+		panic("invalid argument to NewJumpReservoir")
+	}
+	return &JumpReservoir[T]{src: src, keys: keyHeap[T]{k: k}}
+}
+
+func (reservoir *JumpReservoir[T]) Add(item T, weight float64) {
+	if !validWeight(weight) {
+		panic("invalid argument to Add")
+	}
+	if weight == 0 {
+		return
+	}
+	if len(reservoir.keys.items) < reservoir.keys.k {
+		key := math.Log(uniformOpen(reservoir.src)) / weight
+		heap.Push(&reservoir.keys, keyed[T]{item, key})
+		if len(reservoir.keys.items) == reservoir.keys.k {
+			reservoir.newJump()
+		}
+		return
+	}
+
+	reservoir.jump -= weight
+	if reservoir.jump > 0 {
+		return
+	}
+	t := math.Exp(weight * reservoir.keys.items[0].key)
+	u := t + (1-t)*uniformOpen(reservoir.src)
+	reservoir.keys.items[0] = keyed[T]{item, math.Log(u) / weight}
+	heap.Fix(&reservoir.keys, 0)
+	reservoir.newJump()
+}
+
+func (reservoir *JumpReservoir[T]) newJump() {
+	reservoir.jump = math.Log(uniformOpen(reservoir.src)) / reservoir.keys.items[0].key
+}
+
+// A copy of the sample, in no particular (but a deterministic) order.
+func (reservoir *JumpReservoir[T]) Items() []T {
+	return reservoir.keys.values()
+}
+
+func validWeight(weight float64) bool {
+	return weight >= 0 && !math.IsInf(weight, 1)
+}
+
+type keyed[T any] struct {
+	item T
+	key  float64
+}
+
+// A min-heap of at most k keyed items, implementing heap.Interface.
+type keyHeap[T any] struct {
+	k     int
+	items []keyed[T]
+}
+
+func (keys *keyHeap[T]) Len() int           { return len(keys.items) }
+func (keys *keyHeap[T]) Less(i, j int) bool { return keys.items[i].key < keys.items[j].key }
+func (keys *keyHeap[T]) Swap(i, j int)      { keys.items[i], keys.items[j] = keys.items[j], keys.items[i] }
+func (keys *keyHeap[T]) Push(x any)         { keys.items = append(keys.items, x.(keyed[T])) }
+
+func (keys *keyHeap[T]) Pop() any {
+	last := keys.items[len(keys.items)-1]
+	keys.items = keys.items[:len(keys.items)-1]
+	return last
+}
+
+func (keys *keyHeap[T]) values() []T {
+	values := make([]T, len(keys.items))
+	for i, item := range keys.items {
+		values[i] = item.item
+	}
+	return values
+}
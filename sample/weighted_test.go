@@ -0,0 +1,114 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/sample/weighted_test.go
+
+package sample_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/sample"
+)
+
+type weighted interface {
+	Add(item int, weight float64)
+	Items() []int
+}
+
+func Test_WeightedReservoir(t *testing.T) {
+	const trials = 20000
+	weights := []float64{1, 0, 2, 3, 4}
+	tests := []struct {
+		name string
+		make func(src gorng.Source, k int) weighted
+	}{
+		{"A-Res", func(src gorng.Source, k int) weighted { return sample.NewWeightedReservoir[int](src, k) }},
+		{"A-ExpJ", func(src gorng.Source, k int) weighted { return sample.NewJumpReservoir[int](src, k) }},
+	}
+	for _, tt := range tests {
+		// With k = 1, each item is chosen with probability weight / 10.
+		src := gorng.NewSourceFromString(tt.name)
+		counts := make([]float64, len(weights))
+		for range trials {
+			r := tt.make(src, 1)
+			for i, weight := range weights {
+				r.Add(i, weight)
+			}
+			counts[r.Items()[0]]++
+		}
+		if counts[1] != 0 {
+			t.Errorf("%s: zero-weight item chosen %v times", tt.name, counts[1])
+		}
+		chi2 := 0.0
+		for i, weight := range weights {
+			if weight > 0 {
+				expected := trials * weight / 10
+				chi2 += (counts[i] - expected) * (counts[i] - expected) / expected
+			}
+		}
+		// Critical value for 3 degrees of freedom at p = 0.001.
+		if chi2 > 16.266 {
+			t.Errorf("%s: counts %v (chi-square %.2f)", tt.name, counts, chi2)
+		}
+	}
+}
+
+// Both algorithms give the same inclusion probabilities for k > 1.
+func Test_WeightedReservoirsAgree(t *testing.T) {
+	const trials, items, k = 5000, 20, 5
+	inclusion := func(r func() weighted) []float64 {
+		counts := make([]float64, items)
+		for range trials {
+			reservoir := r()
+			for i := range items {
+				reservoir.Add(i, float64(i+1))
+			}
+			for _, item := range reservoir.Items() {
+				counts[item] += 1.0 / trials
+			}
+		}
+		return counts
+	}
+	res := gorng.NewSourceFromString("agree res")
+	expj := gorng.NewSourceFromString("agree expj")
+	a := inclusion(func() weighted { return sample.NewWeightedReservoir[int](res, k) })
+	b := inclusion(func() weighted { return sample.NewJumpReservoir[int](expj, k) })
+	for i := range a {
+		// Binomial standard error is at most 0.5/sqrt(trials), about 0.007.
+		if math.Abs(a[i]-b[i]) > 0.04 {
+			t.Errorf("item %d: A-Res includes it %.3f of the time, A-ExpJ %.3f", i, a[i], b[i])
+		}
+		if i > 0 && a[i] < a[i-1]-0.04 {
+			t.Errorf("item %d is heavier but included less often (%.3f < %.3f)", i, a[i], a[i-1])
+		}
+	}
+}
+
+func Test_WeightedReservoirPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected a negative weight to panic")
+		}
+	}()
+	sample.NewJumpReservoir[int](gorng.NewSourceFromString("panic"), 1).Add(0, -1)
+}
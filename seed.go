@@ -0,0 +1,93 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/seed.go
+
+package gorng
+
+import (
+	"io"
+
+	"github.com/SymbolNotFound/gorng/sha1"
+)
+
+// Constructors for seeds from other sources.  In each case the seed's bytes
+// are written to a new SHA-1 hasher as-is, without any length prefix or tag,
+// so the first digest of the generator is the standard SHA-1 of those bytes.
+// Seeds with the same bytes produce the same sequence regardless of which of
+// these constructors (or NewGenerator) was used.
+
+// Size of the seed read by NewSourceFromReader, enough to fill the generator's
+// 160-bit state.
+const SEED_BYTES = sha1.DIGEST_BYTES
+
+// Creates a new random number generator seeded with the provided bytes; the
+// same as NewGenerator().
+func NewSourceFromBytes(seed []byte) *ShaRing {
+	return NewGenerator(seed)
+}
+
+// Creates a new random number generator seeded with the UTF-8 bytes of seed,
+// for example a human-chosen puzzle or level name.  No normalization is done,
+// so strings that look alike but are encoded differently are different seeds.
+func NewSourceFromString(seed string) *ShaRing {
+	return NewGenerator([]byte(seed))
+}
+
+// Creates a new random number generator seeded with SEED_BYTES bytes read from
+// reader, e.g. crypto/rand.Reader for an unpredictable seed.  Returns an error
+// if fewer bytes than that could be read.
+func NewSourceFromReader(reader io.Reader) (*ShaRing, error) {
+	var seed [SEED_BYTES]byte
+	if _, err := io.ReadFull(reader, seed[:]); err != nil {
+		return nil, err
+	}
+	return NewGenerator(seed[:]), nil
+}
+
+// Folds data into the generator's state by writing it to the hasher.  Any bytes
+// remaining in the current digest are discarded, so the next value comes from a
+// new digest that depends on both the previous state and data.  Two generators
+// at the same position that mix the same data stay in sync.  Rewind() still
+// returns to the state from before any data was mixed in.
+//
+// A CounterRing keeps its position instead: see CounterRing for how mixing
+// changes its stream.
+func (rng *ShaRing) Mix(data []byte) {
+	rng.rng.Write(data)
+	if counter, ok := rng.rng.(*counterHasher); ok && rng.offset != 0 {
+		rng.digest = counter.block(counter.counter - 1)
+		return
+	}
+	rng.offset = 0
+	rng.digest = nil
+}
+
+// Replaces the generator's state with a new seed, discarding the current digest
+// and everything previously seeded or mixed in.  Afterwards the generator
+// produces the same sequence as NewGenerator(seed) would (for the default
+// hasher), and Rewind() returns to the beginning of that new sequence.
+func (rng *ShaRing) Reseed(seed []byte) {
+	rng.rng.Reset()
+	rng.rng.Write(seed)
+	rng.offset = 0
+	rng.digest = nil
+	rng.origin, _ = marshalHasher(rng.rng)
+}
@@ -0,0 +1,120 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/seed_test.go
+
+package gorng_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// NewSourceSeeded writes each value as eight big-endian bytes, for any count.
+func Test_SeededEncoding(t *testing.T) {
+	values := []uint64{0x0123456789ABCDEF, 1, 2, 3, 4, 5}
+	seen := make(map[uint64]int)
+	for count := 1; count <= len(values); count++ {
+		var seed []byte
+		for _, value := range values[:count] {
+			seed = binary.BigEndian.AppendUint64(seed, value)
+		}
+		got := gorng.NewSourceSeeded(values[0], values[1:count]...).Uint64()
+		if want := gorng.NewSourceFromBytes(seed).Uint64(); got != want {
+			t.Errorf("%d seed values: got %x, want %x", count, got, want)
+		}
+		if previous, found := seen[got]; found {
+			t.Errorf("%d seed values collided with %d seed values", count, previous)
+		}
+		seen[got] = count
+	}
+}
+
+func Test_SeedConstructors(t *testing.T) {
+	want := gorng.NewGenerator([]byte("puzzle #42")).NextBits(8 * 40)
+	if got := gorng.NewSourceFromString("puzzle #42").NextBits(8 * 40); !bytes.Equal(got, want) {
+		t.Errorf("NewSourceFromString() diverged\ngot:  %x\nwant: %x", got, want)
+	}
+
+	seed := strings.Repeat("0123456789", 3)
+	rng, err := gorng.NewSourceFromReader(strings.NewReader(seed))
+	if err != nil {
+		t.Fatalf("NewSourceFromReader() error: %s", err)
+	}
+	want = gorng.NewSourceFromBytes([]byte(seed[:gorng.SEED_BYTES])).NextBits(8 * 40)
+	if got := rng.NextBits(8 * 40); !bytes.Equal(got, want) {
+		t.Errorf("NewSourceFromReader() diverged\ngot:  %x\nwant: %x", got, want)
+	}
+
+	if _, err := gorng.NewSourceFromReader(strings.NewReader("short")); err == nil {
+		t.Error("expected an error for a short reader")
+	}
+}
+
+func Test_MixKeepsPeersInSync(t *testing.T) {
+	alice := gorng.NewSourceFromString("match")
+	bob := gorng.NewSourceFromString("match")
+	unmixed := gorng.NewSourceFromString("match")
+
+	for turn, move := range []string{"e4", "e5", "Nf3", "Nc6"} {
+		alice.NextBits(8 * (turn + 3))
+		bob.NextBits(8 * (turn + 3))
+		unmixed.NextBits(8 * (turn + 3))
+		alice.Mix([]byte(move))
+		bob.Mix([]byte(move))
+
+		a, b, u := alice.Uint64(), bob.Uint64(), unmixed.Uint64()
+		if a != b {
+			t.Errorf("turn %d: peers diverged, %x != %x", turn, a, b)
+		}
+		if a == u {
+			t.Errorf("turn %d: mixing %q did not change the stream", turn, move)
+		}
+	}
+
+	if err := alice.Rewind(); err != nil {
+		t.Fatalf("Rewind() error: %s", err)
+	}
+	if got, want := alice.Uint64(), gorng.NewSourceFromString("match").Uint64(); got != want {
+		t.Errorf("Rewind() after Mix() = %x, want %x", got, want)
+	}
+}
+
+func Test_Reseed(t *testing.T) {
+	rng := gorng.NewSourceFromString("before")
+	rng.NextBits(8 * 13)
+	rng.Mix([]byte("noise"))
+	rng.Reseed([]byte("after"))
+
+	want := gorng.NewSourceFromString("after").NextBits(8 * 30)
+	if got := rng.NextBits(8 * 30); !bytes.Equal(got, want) {
+		t.Errorf("Reseed() diverged\ngot:  %x\nwant: %x", got, want)
+	}
+	if err := rng.Rewind(); err != nil {
+		t.Fatalf("Rewind() error: %s", err)
+	}
+	if got := rng.NextBits(8 * 30); !bytes.Equal(got, want) {
+		t.Errorf("Rewind() after Reseed()\ngot:  %x\nwant: %x", got, want)
+	}
+}
@@ -24,6 +24,7 @@ package sha1
 
 import (
 	"encoding/binary"
+	"errors"
 	"io"
 )
 
@@ -33,7 +34,9 @@ type Hasher interface {
 	Reset()
 }
 
-type Digest interface {
+// An alias rather than a defined type, so that hashers from other packages with
+// the same shape of Hash() method (e.g. the sha256 package) satisfy Hasher.
+type Digest = interface {
 	Bytes() []byte
 }
 
@@ -105,6 +108,49 @@ func NewFromDigest(digest Digest) Hasher {
 	return hasher
 }
 
+// Serialized hasher state: an identifier, the chain value, the length and the
+// block, all integers in big-endian byte order.
+const marshalMagic = "sha1"
+const MARSHALED_BYTES = len(marshalMagic) + 4*DIGEST_INTS + 8 + 4*BLOCK_INTS
+
+// Implements encoding.BinaryMarshaler, allowing a partially written message (or
+// a generator's position in its chain of digests) to be saved and restored.
+func (state *hasher) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, MARSHALED_BYTES)
+	data = append(data, marshalMagic...)
+	for _, value := range state.chainValue {
+		data = binary.BigEndian.AppendUint32(data, value)
+	}
+	data = binary.BigEndian.AppendUint64(data, state.length)
+	for _, value := range state.block {
+		data = binary.BigEndian.AppendUint32(data, value)
+	}
+	return data, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, the inverse of MarshalBinary().
+func (state *hasher) UnmarshalBinary(data []byte) error {
+	if len(data) < len(marshalMagic) || string(data[:len(marshalMagic)]) != marshalMagic {
+		return errors.New("sha1: invalid hash state identifier")
+	}
+	if len(data) != MARSHALED_BYTES {
+		return errors.New("sha1: invalid hash state size")
+	}
+	data = data[len(marshalMagic):]
+	for i := range state.chainValue {
+		state.chainValue[i] = binary.BigEndian.Uint32(data)
+		data = data[4:]
+	}
+	state.length = binary.BigEndian.Uint64(data)
+	data = data[8:]
+	for i := range state.block {
+		state.block[i] = binary.BigEndian.Uint32(data)
+		data = data[4:]
+	}
+	clear(state.scratch[:])
+	return nil
+}
+
 // Reset the length, the contents of the block and the initial digest value.
 // If this hasher was created from an existing digest, that digest is forgotten
 // and this will reset back to the NIST-defined initial chain value H_0.
@@ -142,7 +188,6 @@ func (state *hasher) Write(message []byte) (int, error) {
 		index := 64 - offset
 		state.copyBytes(message[:index])
 		state.mixBits()
-		index += offset
 
 		// Repeatedly process while there are more message bytes to write.
 		for index < msglen {
@@ -365,7 +410,7 @@ func write1bit(block *[BLOCK_INTS]uint32, blockpos byte) {
 
 // Constructs a Digest result as byte array, from the five integers of the hash.
 func newDigest(ints [DIGEST_INTS]uint32) Digest {
-	digest := digest{}
+	digest := new(digest)
 	binary.BigEndian.PutUint32(digest.bytes[0:], ints[0])
 	binary.BigEndian.PutUint32(digest.bytes[4:], ints[1])
 	binary.BigEndian.PutUint32(digest.bytes[8:], ints[2])
@@ -378,6 +423,7 @@ type digest struct {
 	bytes [DIGEST_BYTES]byte
 }
 
-func (d digest) Bytes() []byte {
+// Returns the digest's own bytes (not a copy), callers should not modify them.
+func (d *digest) Bytes() []byte {
 	return d.bytes[:]
 }
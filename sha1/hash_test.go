@@ -25,7 +25,8 @@ package sha1_test
 import (
 	"bytes"
 	gosha1 "crypto/sha1" // for reference implementation
-	"math/rand/v2"       // for generating large messages
+	"encoding"
+	"math/rand/v2" // for generating large messages
 	"testing"
 
 	"github.com/SymbolNotFound/gorng/sha1"
@@ -108,3 +109,56 @@ func Test_LargeMonteCarlo(t *testing.T) {
 		})
 	}
 }
+
+// Messages written in several pieces, crossing block boundaries between writes.
+func Test_MultipleWrites(t *testing.T) {
+	message := bytes.Repeat([]byte("0123456789abcdef"), 20)
+	for _, pieces := range [][]int{
+		{10, 310}, {63, 2, 255}, {64, 64, 192}, {1, 100, 1, 218}, {55, 9, 256}} {
+		hasher := sha1.New()
+		offset := 0
+		for _, size := range pieces {
+			hasher.Write(message[offset : offset+size])
+			offset += size
+		}
+		want := gosha1.Sum(message[:offset])
+		if got := hasher.Hash().Bytes(); !bytes.Equal(got, want[:]) {
+			t.Errorf("writes of %v\ngot:  %x\nwant: %x", pieces, got, want)
+		}
+	}
+}
+
+// A hasher restored from a marshaled state continues exactly where it was.
+func Test_MarshalState(t *testing.T) {
+	message := []byte("The quick brown fox jumps over the lazy dog, again and again.")
+	for _, split := range []int{0, 3, 17, 60} {
+		original := sha1.New()
+		original.Write(message[:split])
+		state, err := original.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			t.Fatalf("error when marshaling state: %s", err)
+		}
+		if len(state) != sha1.MARSHALED_BYTES {
+			t.Errorf("marshaled %d bytes, expected %d", len(state), sha1.MARSHALED_BYTES)
+		}
+
+		restored := sha1.New()
+		if err := restored.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+			t.Fatalf("error when unmarshaling state: %s", err)
+		}
+		original.Write(message[split:])
+		restored.Write(message[split:])
+
+		want := gosha1.Sum(message)
+		if got := restored.Hash().Bytes(); !bytes.Equal(got, want[:]) {
+			t.Errorf("restored hash at split %d\ngot:  %x\nwant: %x", split, got, want)
+		}
+		if got := original.Hash().Bytes(); !bytes.Equal(got, want[:]) {
+			t.Errorf("original hash at split %d\ngot:  %x\nwant: %x", split, got, want)
+		}
+	}
+
+	if err := sha1.New().(encoding.BinaryUnmarshaler).UnmarshalBinary([]byte("sha1")); err == nil {
+		t.Error("expected an error for a truncated state")
+	}
+}
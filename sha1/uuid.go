@@ -0,0 +1,119 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/sha1/uuid.go
+
+package sha1
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+)
+
+// A Universally Unique IDentifier, RFC 4122 (now RFC 9562), as its 16 bytes in
+// network (big-endian) order.
+type UUID [16]byte
+
+// The namespaces for names of the kinds defined in RFC 4122, Appendix C.
+var (
+	NamespaceDNS  = MustParseUUID("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceURL  = MustParseUUID("6ba7b811-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceOID  = MustParseUUID("6ba7b812-9dad-11d1-80b4-00c04fd430c8")
+	NamespaceX500 = MustParseUUID("6ba7b814-9dad-11d1-80b4-00c04fd430c8")
+)
+
+var ErrInvalidUUID = errors.New("sha1: invalid UUID")
+
+// Returns the version 5 (name-based, SHA-1) UUID of the name in the namespace:
+// the first 16 bytes of SHA-1(namespace || name) with the version and variant
+// bits set.  The same namespace and name always give the same UUID.
+func NewUUID5(namespace UUID, name []byte) UUID {
+	hasher := New()
+	hasher.Write(namespace[:])
+	hasher.Write(name)
+
+	var uuid UUID
+	copy(uuid[:], hasher.Hash().Bytes())
+	uuid[6] = uuid[6]&0x0f | 0x50 // version 5
+	uuid[8] = uuid[8]&0x3f | 0x80 // variant 10xx
+	return uuid
+}
+
+// The version number from the high four bits of byte 6, e.g. 5 for NewUUID5().
+func (uuid UUID) Version() int {
+	return int(uuid[6] >> 4)
+}
+
+// The canonical form, 32 lowercase hexadecimal digits in groups of 8-4-4-4-12.
+func (uuid UUID) String() string {
+	text, _ := uuid.MarshalText()
+	return string(text)
+}
+
+// Implements encoding.TextMarshaler with the canonical form.
+func (uuid UUID) MarshalText() ([]byte, error) {
+	text := make([]byte, 36)
+	hex.Encode(text[0:8], uuid[0:4])
+	hex.Encode(text[9:13], uuid[4:6])
+	hex.Encode(text[14:18], uuid[6:8])
+	hex.Encode(text[19:23], uuid[8:10])
+	hex.Encode(text[24:], uuid[10:])
+	text[8], text[13], text[18], text[23] = '-', '-', '-', '-'
+	return text, nil
+}
+
+// Implements encoding.TextUnmarshaler, accepting the forms of ParseUUID().
+func (uuid *UUID) UnmarshalText(text []byte) error {
+	parsed, err := ParseUUID(string(text))
+	if err == nil {
+		*uuid = parsed
+	}
+	return err
+}
+
+// Parses a UUID in the canonical form, in upper or lower case, optionally with a
+// "urn:uuid:" prefix or surrounded by braces.
+func ParseUUID(text string) (UUID, error) {
+	var uuid UUID
+	if len(text) == 36+9 && strings.EqualFold(text[:9], "urn:uuid:") {
+		text = text[9:]
+	} else if len(text) == 36+2 && text[0] == '{' && text[37] == '}' {
+		text = text[1:37]
+	}
+	if len(text) != 36 || text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+		return uuid, ErrInvalidUUID
+	}
+	digits := text[0:8] + text[9:13] + text[14:18] + text[19:23] + text[24:]
+	if _, err := hex.Decode(uuid[:], []byte(digits)); err != nil {
+		return UUID{}, ErrInvalidUUID
+	}
+	return uuid, nil
+}
+
+// Like ParseUUID(), but panics if the text is not a valid UUID.  For use with
+// constants, e.g. in package-level variables.
+func MustParseUUID(text string) UUID {
+	uuid, err := ParseUUID(text)
+	if err != nil {
+		panic(err)
+	}
+	return uuid
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/sha1/uuid_test.go
+
+package sha1_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng/sha1"
+)
+
+// Vectors from RFC 9562 (www.example.com) and Python's uuid.uuid5().
+func Test_UUID5(t *testing.T) {
+	tests := []struct {
+		namespace sha1.UUID
+		name      string
+		expected  string
+	}{
+		{sha1.NamespaceDNS, "www.example.com", "2ed6657d-e927-568b-95e1-2665a8aea6a2"},
+		{sha1.NamespaceDNS, "python.org", "886313e1-3b8a-5372-9b90-0c9aee199e5d"},
+		{sha1.NamespaceDNS, "", "4ebd0208-8328-5d69-8c44-ec50939c0967"},
+		{sha1.NamespaceURL, "https://github.com/SymbolNotFound/gorng", "6c8d6a51-6770-5386-a863-b08bd43f7f6c"},
+		{sha1.NamespaceOID, "1.3.6.1.4.1", "106dd502-8b3e-50db-80ed-1134f5c18eae"},
+		{sha1.NamespaceX500, "CN=gorng", "52f6238b-5b30-5056-9682-e6f48d31b910"},
+	}
+	for _, tt := range tests {
+		uuid := sha1.NewUUID5(tt.namespace, []byte(tt.name))
+		if got := uuid.String(); got != tt.expected {
+			t.Errorf("NewUUID5(%s, %q) = %s, want %s", tt.namespace, tt.name, got, tt.expected)
+		}
+		if uuid.Version() != 5 {
+			t.Errorf("NewUUID5(%q).Version() = %d", tt.name, uuid.Version())
+		}
+	}
+}
+
+func Test_ParseUUID(t *testing.T) {
+	want := sha1.NamespaceURL
+	valid := []string{
+		"6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+		"6BA7B811-9DAD-11D1-80B4-00C04FD430C8",
+		"urn:uuid:6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+		"{6ba7b811-9dad-11d1-80b4-00c04fd430c8}",
+	}
+	for _, text := range valid {
+		if uuid, err := sha1.ParseUUID(text); err != nil || uuid != want {
+			t.Errorf("ParseUUID(%q) = %s, %v", text, uuid, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"6ba7b811-9dad-11d1-80b4-00c04fd430c",
+		"6ba7b8119dad11d180b400c04fd430c8",
+		"6ba7b811-9dad-11d1-80b4_00c04fd430c8",
+		"6ba7b811-9dad-11d1-80b4-00c04fd430cg",
+		"{6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+		"uuid:6ba7b811-9dad-11d1-80b4-00c04fd430c8",
+	}
+	for _, text := range invalid {
+		if _, err := sha1.ParseUUID(text); err != sha1.ErrInvalidUUID {
+			t.Errorf("ParseUUID(%q) error = %v, want ErrInvalidUUID", text, err)
+		}
+	}
+}
+
+func Test_UUIDText(t *testing.T) {
+	type record struct {
+		ID sha1.UUID `json:"id"`
+	}
+	original := record{sha1.NewUUID5(sha1.NamespaceDNS, []byte("www.example.com"))}
+	encoded, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("json.Marshal() error: %s", err)
+	}
+	if string(encoded) != `{"id":"2ed6657d-e927-568b-95e1-2665a8aea6a2"}` {
+		t.Errorf("json.Marshal() = %s", encoded)
+	}
+	var decoded record
+	if err := json.Unmarshal(encoded, &decoded); err != nil || decoded != original {
+		t.Errorf("json.Unmarshal() = %v, %v", decoded, err)
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/sha256/hash.go
+
+package sha256
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// The same interface as the sha1 package's Hasher, so that either can be used
+// as the mixing function of a gorng generator.
+type Hasher interface {
+	io.Writer
+	Hash() Digest
+	Reset()
+}
+
+type Digest = interface {
+	Bytes() []byte
+}
+
+// Simple interface for hashing the provided string into a Digest.
+//
+// If intending to call this frequently, allocate the hasher once via New() and
+// call Write(...) / Hash() / Reset() to reuse the block and digest arrays and
+// avoid unnecessary re-allocations.
+func HashString(input string) (Digest, error) {
+	return HashBytes([]byte(input))
+}
+
+// Simple interface for hashing the provided byte-slice into a Digest.
+func HashBytes(input []byte) (Digest, error) {
+	hasher := New()
+	_, err := hasher.Write(input)
+	if err != nil {
+		return nil, err
+	}
+	return hasher.Hash(), nil
+}
+
+// SHA-256 uses the same 512-bit block as SHA-1, in 16 big-endian uint32 words.
+const BLOCK_BITS = 512
+const BLOCK_BYTES = 64
+const BLOCK_INTS = 16
+
+// Reading and writing happens in uint32-sized pieces (aligning |bytes| at 4).
+const BLOCKITEM_MASK = 0b11
+
+// The digest is always 32 bytes, grouped into 8 32-bit words when computing.
+const DIGEST_BYTES = 32
+const DIGEST_INTS = 8
+
+// Size of the temporary scratch buffer (the message schedule) for each block.
+const SCRATCH_INTS = 64
+
+// Internal state for computing the SHA-256 in 512-bit chunks.
+type hasher struct {
+	// The buffer that bytes are written to when preparing to hash.
+	block [BLOCK_INTS]uint32
+	// Counts total |bytes| written,
+	// for tracking the current offset into block and
+	// for writing |bits| at message post-padding
+	length uint64
+	// Allocate only once the scratch space used for hashing each block.
+	scratch [SCRATCH_INTS]uint32
+	// Hashing works on the digest in 32 bit pieces, then
+	// is converted to []byte when finalizing the digest.
+	chainValue [DIGEST_INTS]uint32
+}
+
+// Constructor for a new Hasher instance.
+func New() Hasher {
+	hasher := new(hasher)
+	hasher.Reset()
+	return hasher
+}
+
+func NewFromDigest(digest Digest) Hasher {
+	hasher := new(hasher)
+	bytes := digest.Bytes()
+	for i := range hasher.chainValue {
+		hasher.chainValue[i] = binary.BigEndian.Uint32(bytes[4*i:])
+	}
+	return hasher
+}
+
+// Serialized hasher state: an identifier, the chain value, the length and the
+// block, all integers in big-endian byte order.
+const marshalMagic = "sha2"
+const MARSHALED_BYTES = len(marshalMagic) + 4*DIGEST_INTS + 8 + 4*BLOCK_INTS
+
+// Implements encoding.BinaryMarshaler.
+func (state *hasher) MarshalBinary() ([]byte, error) {
+	data := make([]byte, 0, MARSHALED_BYTES)
+	data = append(data, marshalMagic...)
+	for _, value := range state.chainValue {
+		data = binary.BigEndian.AppendUint32(data, value)
+	}
+	data = binary.BigEndian.AppendUint64(data, state.length)
+	for _, value := range state.block {
+		data = binary.BigEndian.AppendUint32(data, value)
+	}
+	return data, nil
+}
+
+// Implements encoding.BinaryUnmarshaler, the inverse of MarshalBinary().
+func (state *hasher) UnmarshalBinary(data []byte) error {
+	if len(data) < len(marshalMagic) || string(data[:len(marshalMagic)]) != marshalMagic {
+		return errors.New("sha256: invalid hash state identifier")
+	}
+	if len(data) != MARSHALED_BYTES {
+		return errors.New("sha256: invalid hash state size")
+	}
+	data = data[len(marshalMagic):]
+	for i := range state.chainValue {
+		state.chainValue[i] = binary.BigEndian.Uint32(data)
+		data = data[4:]
+	}
+	state.length = binary.BigEndian.Uint64(data)
+	data = data[8:]
+	for i := range state.block {
+		state.block[i] = binary.BigEndian.Uint32(data)
+		data = data[4:]
+	}
+	clear(state.scratch[:])
+	return nil
+}
+
+// Reset the length, the contents of the block and the initial digest value.
+// If this hasher was created from an existing digest, that digest is forgotten
+// and this will reset back to the NIST-defined initial hash value H_0.
+func (state *hasher) Reset() {
+	clear(state.block[:])
+	state.length = 0
+	clear(state.scratch[:])
+	state.chainValue = initialHash
+}
+
+// The first 32 bits of the fractional parts of the square roots of the first
+// eight primes.
+var initialHash = [DIGEST_INTS]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+// Hash the contents of message but leave the buffer ready for additional bytes.
+// That is, it does not add the `1` bit, padding, and message length yet.
+//
+// Satisfies the io.Writer interface similar to other hashing algorithms in Go.
+func (state *hasher) Write(message []byte) (int, error) {
+	msglen := len(message)
+	if msglen == 0 {
+		return 0, nil
+	}
+
+	offset := int(state.length & (BLOCK_BYTES - 1))
+	if msglen+offset < BLOCK_BYTES {
+		// Write entire message, it will fit within the current block.
+		state.copyBytes(message)
+	} else { // More bytes in `message` than can fit within the block's capacity,
+		// process enough to fill the current buffer and then process the rest.
+		index := 64 - offset
+		state.copyBytes(message[:index])
+		state.mixBits()
+
+		// Repeatedly process while there are more message bytes to write.
+		for index < msglen {
+			next := index + BLOCK_BYTES
+			if next > msglen {
+				next = msglen
+			}
+			state.copyBytes(message[index:next])
+			if next-index == BLOCK_BYTES {
+				state.mixBits()
+			}
+			index = next
+		}
+	}
+
+	return msglen, nil
+}
+
+// Copies the bytes in `message` into a sequence of integers (big-endian). The
+// message slice should have no more bytes than can fit in the current block.
+func (state *hasher) copyBytes(message []byte) {
+	msgi := uint32(0)
+	msglen := uint32(len(message))
+	length := state.length
+	blocki := uint32(state.length&63) >> 2
+	value := state.block[blocki]
+
+	// Copy bytes in uint32 chunks, using big-endian order.
+	for msgi < msglen {
+		value = (value << 8) + uint32(message[msgi])
+		msgi, length = msgi+1, length+1
+		if length&BLOCKITEM_MASK == 0 {
+			state.block[blocki] = value
+			value, blocki = 0, blocki+1
+		}
+	}
+	// Write partial value if the loop above didn't end at a uint32 boundary.
+	if length&BLOCKITEM_MASK != 0 {
+		state.block[blocki] = value
+	}
+	state.length = length
+}
+
+// Applies the SHA-256 compression function to the contents of the current
+// block, as defined by the Secure Hash Standard published by NIST in
+// [FIPS PUB 180-4](https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.180-4.pdf).
+//
+// (prepare the message schedule W, a scratch space of 64 uint32)
+// W_t = M_t                                                    0 ≤ t ≤ 15
+// W_t = σ1( W_(t-2) ) + W_(t-7) + σ0( W_(t-15) ) + W_(t-16)   16 ≤ t ≤ 63
+//
+// (initialize working variables {a, b, c, d, e, f, g, h} from H_0 .. H_7)
+//
+// (for t from 0 to 63, mix the bits, let T1 and T2 be temporary uint32)
+// T1 = h + Σ1( e ) + Ch( e, f, g ) + K_t + W_t
+// T2 = Σ0( a ) + Maj( a, b, c )
+// h = g
+// g = f
+// f = e
+// e = d + T1
+// d = c
+// c = b
+// b = a
+// a = T1 + T2
+//
+// where, with ROTR[n] a right rotation and SHR[n] a right shift,
+// Σ0(x) = ROTR[2](x) (+) ROTR[13](x) (+) ROTR[22](x)
+// Σ1(x) = ROTR[6](x) (+) ROTR[11](x) (+) ROTR[25](x)
+// σ0(x) = ROTR[7](x) (+) ROTR[18](x) (+) SHR[3](x)
+// σ1(x) = ROTR[17](x) (+) ROTR[19](x) (+) SHR[10](x)
+// Ch(x, y, z) = (x & y) (+) (~x & z)
+// Maj(x, y, z) = (x & y) (+) (x & z) (+) (y & z)
+//
+// Before the function returns it will clear the contents of the block and
+// scratch memory.  The digest value will be updated in-place.
+func (state *hasher) mixBits() {
+	// Prepare the message schedule, expanded from the words of the current block.
+	for i := 0; i < 16; i++ {
+		state.scratch[i] = state.block[i]
+	}
+	for i := 16; i < SCRATCH_INTS; i++ {
+		w2, w15 := state.scratch[i-2], state.scratch[i-15]
+		sigma1 := rotateR(w2, 17) ^ rotateR(w2, 19) ^ (w2 >> 10)
+		sigma0 := rotateR(w15, 7) ^ rotateR(w15, 18) ^ (w15 >> 3)
+		state.scratch[i] = sigma1 + state.scratch[i-7] + sigma0 + state.scratch[i-16]
+	}
+
+	// Initial values of working memory are based on the chaining value thus far.
+	a := state.chainValue[0]
+	b := state.chainValue[1]
+	c := state.chainValue[2]
+	d := state.chainValue[3]
+	e := state.chainValue[4]
+	f := state.chainValue[5]
+	g := state.chainValue[6]
+	h := state.chainValue[7]
+
+	for i := 0; i < SCRATCH_INTS; i++ {
+		t1 := h +
+			(rotateR(e, 6) ^ rotateR(e, 11) ^ rotateR(e, 25)) +
+			((e & f) ^ (^e & g)) +
+			K[i] + state.scratch[i]
+		t2 := (rotateR(a, 2) ^ rotateR(a, 13) ^ rotateR(a, 22)) +
+			((a & b) ^ (a & c) ^ (b & c))
+		h = g
+		g = f
+		f = e
+		e = d + t1
+		d = c
+		c = b
+		b = a
+		a = t1 + t2
+	}
+
+	// Add the resulting values back to the digest (truncated to 2^32)
+	state.chainValue[0] += a
+	state.chainValue[1] += b
+	state.chainValue[2] += c
+	state.chainValue[3] += d
+	state.chainValue[4] += e
+	state.chainValue[5] += f
+	state.chainValue[6] += g
+	state.chainValue[7] += h
+
+	// Clear the block and scratch space after processing.
+	clear(state.block[:])   // With the bits zeroed, padding can be automatic.
+	clear(state.scratch[:]) // Not strictly necessary but leaves less evidence.
+}
+
+// The first 32 bits of the fractional parts of the cube roots of the first
+// sixty-four primes.
+var K = [SCRATCH_INTS]uint32{
+	0x428a2f98, 0x71374491, 0xb5c0fbcf, 0xe9b5dba5,
+	0x3956c25b, 0x59f111f1, 0x923f82a4, 0xab1c5ed5,
+	0xd807aa98, 0x12835b01, 0x243185be, 0x550c7dc3,
+	0x72be5d74, 0x80deb1fe, 0x9bdc06a7, 0xc19bf174,
+	0xe49b69c1, 0xefbe4786, 0x0fc19dc6, 0x240ca1cc,
+	0x2de92c6f, 0x4a7484aa, 0x5cb0a9dc, 0x76f988da,
+	0x983e5152, 0xa831c66d, 0xb00327c8, 0xbf597fc7,
+	0xc6e00bf3, 0xd5a79147, 0x06ca6351, 0x14292967,
+	0x27b70a85, 0x2e1b2138, 0x4d2c6dfc, 0x53380d13,
+	0x650a7354, 0x766a0abb, 0x81c2c92e, 0x92722c85,
+	0xa2bfe8a1, 0xa81a664b, 0xc24b8b70, 0xc76c51a3,
+	0xd192e819, 0xd6990624, 0xf40e3585, 0x106aa070,
+	0x19a4c116, 0x1e376c08, 0x2748774c, 0x34b0bcb5,
+	0x391c0cb3, 0x4ed8aa4a, 0x5b9cca4f, 0x682e6ff3,
+	0x748f82ee, 0x78a5636f, 0x84c87814, 0x8cc70208,
+	0x90befffa, 0xa4506ceb, 0xbef9a3f7, 0xc67178f2,
+}
+
+// Convenience function, rotates the bits of an unsigned 32-bit integer.
+func rotateR(value uint32, bits int) uint32 {
+	return uint32(value>>bits) | uint32(value<<(32-bits))
+}
+
+// Performs the final post-processing and returns the message hash as a Digest.
+// As with the sha1 package, the chain value is kept, so calling Hash() again
+// continues a chain of digests rather than repeating the same one.
+func (state *hasher) Hash() Digest {
+	length := state.length
+
+	// Write a single `1` bit before the rest of the padding.
+	write1bit(&state.block, byte(length&63))
+
+	// Leave room at the end of the final block for the message length.
+	if length&63 >= 56 {
+		// current block is too full for length value, mix bits and use next block.
+		state.length += 64 - (length & 63)
+		state.mixBits()
+	}
+
+	state.block[BLOCK_INTS-2] = uint32(length >> 29)
+	state.block[BLOCK_INTS-1] = uint32(length&0x1FFFFFFF) << 3
+	state.length += 64 - (state.length & 63)
+	state.mixBits()
+
+	digest := newDigest(state.chainValue)
+	state.length = length
+	clear(state.block[:])
+	return digest
+}
+
+// Writes a single `1` bit after the message contents.  The blockpos is the
+// length of the written contents of block, 0 <= blockpos < BLOCK_BYTES.
+func write1bit(block *[BLOCK_INTS]uint32, blockpos byte) {
+	blocki := blockpos >> 2
+	switch blockpos & BLOCKITEM_MASK {
+	case 0:
+		block[blocki] = 0x80_00_00_00
+	case 1:
+		block[blocki] = (block[blocki] << 24) | 0x00_80_00_00
+	case 2:
+		block[blocki] = (block[blocki] << 16) | 0x00_00_80_00
+	case 3:
+		block[blocki] = (block[blocki] << 8) | 0x00_00_00_80
+	}
+}
+
+// Constructs a Digest result as byte array, from the eight integers of the hash.
+func newDigest(ints [DIGEST_INTS]uint32) Digest {
+	digest := new(digest)
+	for i, value := range ints {
+		binary.BigEndian.PutUint32(digest.bytes[4*i:], value)
+	}
+	return digest
+}
+
+type digest struct {
+	bytes [DIGEST_BYTES]byte
+}
+
+// Returns the digest's own bytes (not a copy), callers should not modify them.
+func (d *digest) Bytes() []byte {
+	return d.bytes[:]
+}
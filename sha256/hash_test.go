@@ -0,0 +1,115 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/sha256/hash_test.go
+
+package sha256_test
+
+import (
+	"bytes"
+	gosha256 "crypto/sha256" // for reference implementation
+	"encoding"
+	"encoding/hex"
+	"math/rand/v2" // for generating large messages
+	"testing"
+
+	"github.com/SymbolNotFound/gorng/sha1"
+	"github.com/SymbolNotFound/gorng/sha256"
+)
+
+// Hashers from either package can be used wherever a sha1.Hasher is expected.
+var _ sha1.Hasher = sha256.New()
+
+func Test_Hashing(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "",
+			"e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{"abc", "abc",
+			"ba7816bf8f01cfea414140de5dae2223b00361a396177a9cb410ff61f20015ad"},
+		{"two blocks", "abcdbcdecdefdefgefghfghighijhijkijkljklmklmnlmnomnopnopq",
+			"248d6a61d20638b8e5c026930c3e6039a33ce45964ff2167f6ecedd419db06c1"},
+		{"lazy dog", "The quick brown fox jumps over the lazy dog",
+			"d7a8fbb307d7809469ca9abcb0082e4f8d5651e46d3cdb762d02d0bf37c9e592"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, _ := hex.DecodeString(tt.expected)
+			gosh := gosha256.Sum256([]byte(tt.input))
+			if !bytes.Equal(gosh[:], expected) {
+				t.Errorf("hash for %s is likely incorrect...\nGo SHA256: %x\nexpected:  %x",
+					tt.name, gosh[:], expected)
+			}
+
+			digest, err := sha256.HashString(tt.input)
+			if err != nil {
+				t.Errorf("error when attempting to hash input '%s':\n%s", tt.input, err)
+			}
+			if !bytes.Equal(digest.Bytes(), expected) {
+				t.Errorf("hashing of test '%s' resulted in unexpected hash\ngot:  %x\nwant: %x",
+					tt.name, digest.Bytes(), expected)
+			}
+		})
+	}
+}
+
+// Randomly generates messages of various sizes, written in one or two pieces,
+// and checks their digest against Go's standard library implementation.
+func Test_MonteCarlo(t *testing.T) {
+	rng := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	for _, size := range []int{55, 56, 63, 64, 65, 119, 120, 1000, 1<<20 + 1} {
+		input := make([]byte, size)
+		for i := range input {
+			input[i] = byte(rng.Uint32())
+		}
+		split := rng.IntN(size)
+
+		hasher := sha256.New()
+		hasher.Write(input[:split])
+		hasher.Write(input[split:])
+		gosh := gosha256.Sum256(input)
+		if got := hasher.Hash().Bytes(); !bytes.Equal(got, gosh[:]) {
+			t.Errorf("size %d split at %d\ngot:  %x\nwant: %x", size, split, got, gosh)
+		}
+	}
+}
+
+func Test_MarshalState(t *testing.T) {
+	message := []byte("The quick brown fox jumps over the lazy dog, again and again.")
+	original := sha256.New()
+	original.Write(message[:21])
+	state, err := original.(encoding.BinaryMarshaler).MarshalBinary()
+	if err != nil {
+		t.Fatalf("error when marshaling state: %s", err)
+	}
+
+	restored := sha256.New()
+	if err := restored.(encoding.BinaryUnmarshaler).UnmarshalBinary(state); err != nil {
+		t.Fatalf("error when unmarshaling state: %s", err)
+	}
+	restored.Write(message[21:])
+	want := gosha256.Sum256(message)
+	if got := restored.Hash().Bytes(); !bytes.Equal(got, want[:]) {
+		t.Errorf("restored hash\ngot:  %x\nwant: %x", got, want)
+	}
+}
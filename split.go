@@ -0,0 +1,82 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/split.go
+
+package gorng
+
+import (
+	"encoding"
+
+	"github.com/SymbolNotFound/gorng/sha1"
+)
+
+// Deriving child generators from a parent.  Each child is a chained ShaRing
+// (as from NewGenerator) seeded with a domain-separation tag followed by bytes
+// taken from the parent, so that children are independent of the parent's
+// own stream and of each other:
+//
+//	Split():       seed = "gorng/split\x00"  || next 20 bytes of the parent
+//	Derive(label): seed = "gorng/derive\x00" || D || label
+//
+// where D is the next digest the parent's hasher would produce, i.e. the digest
+// that the parent reads from when it next needs more bytes.  Derive() does not
+// depend on the parent's offset into its current digest.  Children always use
+// the SHA-1 hasher, whichever Hasher the parent was created with.
+
+const splitTag = "gorng/split\x00"
+const deriveTag = "gorng/derive\x00"
+
+// Returns a new generator seeded from the next 20 bytes of this one, which
+// advances this generator.  Calling Split() repeatedly gives distinct children.
+func (rng *ShaRing) Split() *ShaRing {
+	var key [sha1.DIGEST_BYTES]byte
+	rng.fill(key[:])
+
+	child := sha1.New()
+	child.Write([]byte(splitTag))
+	child.Write(key[:])
+	return newRing(child)
+}
+
+// Returns a new generator for the label without advancing this one; the same
+// label always derives the same child until this generator reads a new digest.
+// Panics with ErrNotMarshalable if the hasher's state can't be saved, because
+// peeking at the next digest requires restoring the hasher afterwards.
+func (rng *ShaRing) Derive(label []byte) *ShaRing {
+	state, err := marshalHasher(rng.rng)
+	if err != nil {
+		panic(err)
+	}
+	unmarshaler, ok := rng.rng.(encoding.BinaryUnmarshaler)
+	if !ok {
+		panic(ErrNotMarshalable)
+	}
+	next := rng.rng.Hash()
+	if err := unmarshaler.UnmarshalBinary(state); err != nil {
+		panic(err)
+	}
+
+	child := sha1.New()
+	child.Write([]byte(deriveTag))
+	child.Write(next.Bytes())
+	child.Write(label)
+	return newRing(child)
+}
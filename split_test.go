@@ -0,0 +1,115 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/split_test.go
+
+package gorng_test
+
+import (
+	"bytes"
+	gosha1 "crypto/sha1" // for reference implementation
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/sha1"
+)
+
+// Golden vectors: the first 16 bytes of each child of NewGenerator("parent").
+func Test_SplitDeriveVectors(t *testing.T) {
+	parent := gorng.NewGenerator([]byte("parent"))
+	splits := []string{
+		"04194d745d53d7c48e773283545e072b",
+		"f2ce983e19dee991cabdd245ad1894f1",
+	}
+	for i, want := range splits {
+		if got := hex.EncodeToString(parent.Split().NextBits(128)); got != want {
+			t.Errorf("Split() #%d = %s, want %s", i, got, want)
+		}
+	}
+
+	parent = gorng.NewGenerator([]byte("parent"))
+	derived := []struct {
+		label    []byte
+		expected string
+	}{
+		{[]byte("player-1"), "2200b8ec05a574c7ffc5a79e4a5b8c0b"},
+		{[]byte("player-2"), "8b316742d403b0f3fecb8faac1afe6c1"},
+		{nil, "d4cf562682d7730128a5eff8d65c6a4a"},
+		// Long enough that the label's write crosses a SHA-1 block boundary.
+		{[]byte("level/forest/chest-0042/contents/loot"), "c2d9e05068bd3e587dc32ca65e5552e9"},
+	}
+	for _, tt := range derived {
+		got := hex.EncodeToString(parent.Derive(tt.label).NextBits(128))
+		if got != tt.expected {
+			t.Errorf("Derive(%q) = %s, want %s", tt.label, got, tt.expected)
+		}
+	}
+}
+
+// The derivation scheme, restated with the standard library's SHA-1.
+func Test_SplitDeriveScheme(t *testing.T) {
+	seed := []byte("scheme")
+	first := gosha1.Sum(seed)
+
+	split := gosha1.Sum(append([]byte("gorng/split\x00"), first[:]...))
+	if got := gorng.NewGenerator(seed).Split().NextBits(160); !bytes.Equal(got, split[:]) {
+		t.Errorf("Split() child\ngot:  %x\nwant: %x", got, split)
+	}
+
+	message := append([]byte("gorng/derive\x00"), first[:]...)
+	derive := gosha1.Sum(append(message, "label"...))
+	if got := gorng.NewGenerator(seed).Derive([]byte("label")).NextBits(160); !bytes.Equal(got, derive[:]) {
+		t.Errorf("Derive() child\ngot:  %x\nwant: %x", got, derive)
+	}
+}
+
+// Derive() leaves the parent untouched, Split() advances it.
+func Test_DeriveIsPure(t *testing.T) {
+	want := gorng.NewGenerator([]byte("pure")).NextBits(8 * 100)
+
+	rng := gorng.NewGenerator([]byte("pure"))
+	rng.NextBits(8 * 7)
+	one := rng.Derive([]byte("child")).Uint64()
+	two := rng.Derive([]byte("child")).Uint64()
+	if one != two {
+		t.Errorf("Derive() is not repeatable: %x != %x", one, two)
+	}
+	if got := rng.NextBits(8 * 93); !bytes.Equal(got, want[7:]) {
+		t.Errorf("Derive() changed the parent stream\ngot:  %x\nwant: %x", got, want[7:])
+	}
+
+	split := gorng.NewGenerator([]byte("pure"))
+	split.Split()
+	if got := split.NextBits(8 * 80); !bytes.Equal(got, want[20:]) {
+		t.Errorf("Split() should consume 20 bytes\ngot:  %x\nwant: %x", got, want[20:])
+	}
+}
+
+func Test_DeriveNotUnmarshalable(t *testing.T) {
+	rng := gorng.New(marshalOnlyHasher{sha1.New()})
+	defer func() {
+		if err, _ := recover().(error); !errors.Is(err, gorng.ErrNotMarshalable) {
+			t.Errorf("Derive() panicked with %v, want ErrNotMarshalable", err)
+		}
+	}()
+	rng.Derive([]byte("label"))
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/state.go
+
+package gorng
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+
+	"github.com/SymbolNotFound/gorng/sha1"
+)
+
+// Saving and restoring the position of a generator in its stream.  The state
+// is the hasher's state plus the current digest and how much of it has been
+// consumed, so that a restored generator continues with exactly the same bytes.
+//
+// The binary format (version 1) is
+//
+//	"ring" | version (1 byte) | offset (1 byte) | digest size (1 byte) |
+//	digest (digest size bytes) | hasher state
+//
+// where the hasher state is whatever the hasher's MarshalBinary() produces, and
+// the digest is omitted (its size is written as 0) when offset is 0.
+// Open channels and the state that Rewind() returns to are not included.
+
+const stateMagic = "ring"
+const stateVersion = 1
+
+var ErrNotMarshalable = errors.New("gorng: hasher does not support marshaling its state")
+var ErrInvalidState = errors.New("gorng: invalid generator state")
+
+// Implements encoding.BinaryMarshaler.
+func (rng *ShaRing) MarshalBinary() ([]byte, error) {
+	hasherState, err := marshalHasher(rng.rng)
+	if err != nil {
+		return nil, err
+	}
+	var digest []byte
+	if rng.offset != 0 {
+		digest = rng.digest.Bytes()
+	}
+	if len(digest) > 255 {
+		return nil, ErrNotMarshalable
+	}
+	data := make([]byte, 0, len(stateMagic)+3+len(digest)+len(hasherState))
+	data = append(data, stateMagic...)
+	data = append(data, stateVersion, byte(rng.offset), byte(len(digest)))
+	data = append(data, digest...)
+	return append(data, hasherState...), nil
+}
+
+// Implements encoding.BinaryUnmarshaler.  If the generator does not have a
+// hasher yet (e.g. it is a zero ShaRing) then the default SHA-1 hasher is used.
+func (rng *ShaRing) UnmarshalBinary(data []byte) error {
+	header := len(stateMagic) + 3
+	if len(data) < header ||
+		string(data[:len(stateMagic)]) != stateMagic ||
+		data[len(stateMagic)] != stateVersion {
+		return ErrInvalidState
+	}
+	offset := int(data[len(stateMagic)+1])
+	size := int(data[len(stateMagic)+2])
+	if (offset != 0 && offset >= size) || len(data) < header+size {
+		return ErrInvalidState
+	}
+
+	if rng.rng == nil {
+		rng.rng = sha1.New()
+	}
+	unmarshaler, ok := rng.rng.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return ErrNotMarshalable
+	}
+	if err := unmarshaler.UnmarshalBinary(data[header+size:]); err != nil {
+		return err
+	}
+	rng.offset = offset
+	rng.digest = nil
+	if offset != 0 {
+		rng.digest = digestBytes(bytes.Clone(data[header : header+size]))
+	}
+	return nil
+}
+
+// Implements encoding.TextMarshaler, the binary state in standard base64.
+func (rng *ShaRing) MarshalText() ([]byte, error) {
+	data, err := rng.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.AppendEncode(nil, data), nil
+}
+
+// Implements encoding.TextUnmarshaler, the inverse of MarshalText().
+func (rng *ShaRing) UnmarshalText(text []byte) error {
+	data, err := base64.StdEncoding.AppendDecode(nil, text)
+	if err != nil {
+		return ErrInvalidState
+	}
+	return rng.UnmarshalBinary(data)
+}
+
+// Returns a short, URL-safe string that LoadSaveCode() can restore the exact
+// state of this generator from.  The binary state usually ends in a run of
+// zero bytes (the hasher's cleared block), so the code is the length of the
+// state as a uvarint followed by the state with trailing zeros removed, all
+// encoded as unpadded base64url.
+func (rng *ShaRing) SaveCode() (string, error) {
+	data, err := rng.MarshalBinary()
+	if err != nil {
+		return "", err
+	}
+	code := binary.AppendUvarint(nil, uint64(len(data)))
+	code = append(code, bytes.TrimRight(data, "\x00")...)
+	return base64.RawURLEncoding.EncodeToString(code), nil
+}
+
+// Creates a generator (using the default SHA-1 hasher) from a SaveCode().
+// The restored state also becomes the one that Rewind() returns to.
+func LoadSaveCode(code string) (*ShaRing, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(code)
+	if err != nil {
+		return nil, ErrInvalidState
+	}
+	size, read := binary.Uvarint(decoded)
+	if read <= 0 || size < uint64(len(decoded)-read) || size > 1<<16 {
+		return nil, ErrInvalidState
+	}
+	data := make([]byte, size)
+	copy(data, decoded[read:])
+
+	rng := &ShaRing{}
+	if err := rng.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	rng.origin, _ = marshalHasher(rng.rng)
+	return rng, nil
+}
+
+// An in-memory snapshot of a generator's position, see Bookmark().
+type Bookmark struct {
+	state []byte
+}
+
+// Saves the current position, which Restore() can later return to.
+func (rng *ShaRing) Bookmark() (Bookmark, error) {
+	state, err := rng.MarshalBinary()
+	return Bookmark{state}, err
+}
+
+// Returns the generator to a position saved by Bookmark().
+func (rng *ShaRing) Restore(bookmark Bookmark) error {
+	return rng.UnmarshalBinary(bookmark.state)
+}
+
+// Returns the generator to the beginning of its sequence, the state it had
+// just after it was seeded.  Fails if the hasher's state can't be marshaled.
+func (rng *ShaRing) Rewind() error {
+	if rng.origin == nil {
+		return ErrNotMarshalable
+	}
+	unmarshaler, ok := rng.rng.(encoding.BinaryUnmarshaler)
+	if !ok {
+		return ErrNotMarshalable
+	}
+	if err := unmarshaler.UnmarshalBinary(rng.origin); err != nil {
+		return err
+	}
+	rng.offset = 0
+	rng.digest = nil
+	return nil
+}
+
+func marshalHasher(source Hasher) ([]byte, error) {
+	marshaler, ok := source.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, ErrNotMarshalable
+	}
+	return marshaler.MarshalBinary()
+}
+
+// A Digest restored from its bytes.
+type digestBytes []byte
+
+func (digest digestBytes) Bytes() []byte {
+	return digest
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/state_test.go
+
+package gorng_test
+
+import (
+	"bytes"
+	"encoding"
+	"errors"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+	"github.com/SymbolNotFound/gorng/sha1"
+)
+
+func Test_MarshalRoundTrip(t *testing.T) {
+	// Offsets both aligned and unaligned with the digest boundaries.
+	for _, skip := range []int{0, 1, 8, 19, 20, 37, 100} {
+		rng := gorng.NewGenerator([]byte("marshal"))
+		rng.NextBits(8 * skip)
+
+		data, err := rng.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() error: %s", err)
+		}
+		text, err := rng.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText() error: %s", err)
+		}
+		code, err := rng.SaveCode()
+		if err != nil {
+			t.Fatalf("SaveCode() error: %s", err)
+		}
+		want := rng.NextBits(8 * 64)
+
+		fromBinary := gorng.New(nil)
+		if err := fromBinary.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary() error: %s", err)
+		}
+		fromText := new(gorng.ShaRing)
+		if err := fromText.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText() error: %s", err)
+		}
+		fromCode, err := gorng.LoadSaveCode(code)
+		if err != nil {
+			t.Fatalf("LoadSaveCode(%q) error: %s", code, err)
+		}
+
+		for name, restored := range map[string]*gorng.ShaRing{
+			"binary": fromBinary, "text": fromText, "code": fromCode} {
+			if got := restored.NextBits(8 * 64); !bytes.Equal(got, want) {
+				t.Errorf("skip %d, %s state diverged\ngot:  %x\nwant: %x",
+					skip, name, got, want)
+			}
+		}
+	}
+}
+
+func Test_BookmarkRewind(t *testing.T) {
+	rng := gorng.NewGenerator([]byte("bookmark"))
+	start := rng.NextBits(8 * 30)
+
+	bookmark, err := rng.Bookmark()
+	if err != nil {
+		t.Fatalf("Bookmark() error: %s", err)
+	}
+	marked := rng.NextBits(8 * 50)
+	rng.Uint64()
+
+	if err := rng.Restore(bookmark); err != nil {
+		t.Fatalf("Restore() error: %s", err)
+	}
+	if again := rng.NextBits(8 * 50); !bytes.Equal(again, marked) {
+		t.Errorf("restored bookmark diverged\ngot:  %x\nwant: %x", again, marked)
+	}
+
+	if err := rng.Rewind(); err != nil {
+		t.Fatalf("Rewind() error: %s", err)
+	}
+	if again := rng.NextBits(8 * 30); !bytes.Equal(again, start) {
+		t.Errorf("rewound generator diverged\ngot:  %x\nwant: %x", again, start)
+	}
+}
+
+func Test_InvalidState(t *testing.T) {
+	for _, code := range []string{"", "!!", "AAAA", "Zm9vYmFy"} {
+		if _, err := gorng.LoadSaveCode(code); err == nil {
+			t.Errorf("LoadSaveCode(%q) expected an error", code)
+		}
+	}
+	if err := new(gorng.ShaRing).UnmarshalBinary([]byte("ring")); err == nil {
+		t.Error("UnmarshalBinary() expected an error for a truncated state")
+	}
+}
+
+// A hasher whose state can be saved but not restored.
+type marshalOnlyHasher struct {
+	sha1.Hasher
+}
+
+func (hasher marshalOnlyHasher) MarshalBinary() ([]byte, error) {
+	return hasher.Hasher.(encoding.BinaryMarshaler).MarshalBinary()
+}
+
+func Test_RewindNotUnmarshalable(t *testing.T) {
+	rng := gorng.New(marshalOnlyHasher{sha1.New()})
+	rng.Uint64()
+	if err := rng.Rewind(); !errors.Is(err, gorng.ErrNotMarshalable) {
+		t.Errorf("Rewind() error = %v, want ErrNotMarshalable", err)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2024 Symbol Not Found LLC
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+//
+// github.com:SymbolNotFound/gorng/vectors_test.go
+
+package gorng_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/SymbolNotFound/gorng"
+)
+
+// Validates the checked-in golden vectors (see cmd/vectors) against the current
+// implementation.  If this fails, either the change broke compatibility with
+// existing ports or the fixture needs a new version.
+func Test_GoldenVectors(t *testing.T) {
+	for version := 1; version <= 2; version++ {
+		t.Run(fmt.Sprintf("v%d", version), func(t *testing.T) {
+			checkVectors(t, fmt.Sprintf("testdata/vectors_v%d.json", version), version)
+		})
+	}
+}
+
+// Published fixtures are frozen, each one is checked against the version in
+// its filename.
+func checkVectors(t *testing.T, path string, version int) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("could not read fixture: %s", err)
+	}
+	var fixture struct {
+		Version int `json:"version"`
+		Vectors []struct {
+			Name        string   `json:"name"`
+			Constructor string   `json:"constructor"`
+			Seed        string   `json:"seed"`
+			Seeds       []string `json:"seeds"`
+			Digest      string   `json:"digest"`
+			Uint64      []string `json:"uint64"`
+			Bounded     []struct {
+				N      string   `json:"n"`
+				Values []string `json:"values"`
+			} `json:"bounded"`
+			Bits []struct {
+				Bits   int      `json:"bits"`
+				Values []string `json:"values"`
+			} `json:"bits"`
+		} `json:"vectors"`
+	}
+	if err := json.Unmarshal(data, &fixture); err != nil {
+		t.Fatalf("could not parse fixture: %s", err)
+	}
+	if fixture.Version != version || len(fixture.Vectors) == 0 {
+		t.Fatalf("unexpected fixture version %d with %d vectors",
+			fixture.Version, len(fixture.Vectors))
+	}
+
+	for _, vector := range fixture.Vectors {
+		t.Run(vector.Name, func(t *testing.T) {
+			var fresh func() *gorng.ShaRing
+			switch vector.Constructor {
+			case "NewGenerator":
+				seed := mustHex(t, vector.Seed)
+				fresh = func() *gorng.ShaRing { return gorng.NewGenerator(seed) }
+			case "NewSourceSeeded":
+				seeds := make([]uint64, len(vector.Seeds))
+				for i, seed := range vector.Seeds {
+					seeds[i] = mustUint64(t, seed)
+				}
+				fresh = func() *gorng.ShaRing {
+					return gorng.NewSourceSeeded(seeds[0], seeds[1:]...)
+				}
+			case "NewSourceDigest":
+				digest := fixedDigest(mustHex(t, vector.Digest))
+				fresh = func() *gorng.ShaRing { return gorng.NewSourceDigest(digest) }
+			default:
+				t.Fatalf("unknown constructor %q", vector.Constructor)
+			}
+
+			rng := fresh()
+			for i, value := range vector.Uint64 {
+				if got := rng.Uint64(); got != mustUint64(t, value) {
+					t.Errorf("Uint64() #%d = %#x, want %s", i, got, value)
+				}
+			}
+			for _, bounded := range vector.Bounded {
+				rng := fresh()
+				n := mustUint64(t, bounded.N)
+				for i, value := range bounded.Values {
+					if got := rng.Uint64N(n); got != mustUint64(t, value) {
+						t.Errorf("Uint64N(%#x) #%d = %#x, want %s", n, i, got, value)
+					}
+				}
+			}
+			for _, bits := range vector.Bits {
+				rng := fresh()
+				for i, value := range bits.Values {
+					if got := hex.EncodeToString(rng.NextBits(bits.Bits)); got != value {
+						t.Errorf("NextBits(%d) #%d = %s, want %s", bits.Bits, i, got, value)
+					}
+				}
+			}
+		})
+	}
+}
+
+func mustHex(t *testing.T, encoded string) []byte {
+	t.Helper()
+	decoded, err := hex.DecodeString(encoded)
+	if err != nil {
+		t.Fatalf("invalid hex %q: %s", encoded, err)
+	}
+	return decoded
+}
+
+func mustUint64(t *testing.T, encoded string) uint64 {
+	t.Helper()
+	value, err := strconv.ParseUint(encoded, 0, 64)
+	if err != nil {
+		t.Fatalf("invalid uint64 %q: %s", encoded, err)
+	}
+	return value
+}